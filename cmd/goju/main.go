@@ -1,16 +1,23 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/make17better/goju/internal/config"
 	"github.com/make17better/goju/internal/learn"
 	"github.com/make17better/goju/internal/lookup"
 	"github.com/make17better/goju/internal/practise"
+	"github.com/make17better/goju/internal/speech"
+	"github.com/make17better/goju/internal/strokes"
 	"github.com/make17better/goju/internal/ui"
+	"github.com/make17better/goju/pkg/goju"
 )
 
 const (
@@ -33,6 +40,42 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Subcommands parse their own flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "convert":
+			if err := runConvert(os.Args[2:], os.Stdin, os.Stdout); err != nil && !helpRequested(err) {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "strokes":
+			if err := runStrokes(os.Args[2:], os.Stdout); err != nil && !helpRequested(err) {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "speak":
+			if err := runSpeak(os.Args[2:]); err != nil && !helpRequested(err) {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "lookup":
+			if err := runLookup(os.Args[2:], cfg); err != nil && !helpRequested(err) {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "mnemonic":
+			if err := runMnemonic(os.Args[2:], cfg); err != nil && !helpRequested(err) {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Define flags
 	helpFlag := flag.Bool("help", false, "Show help information")
 	versionFlag := flag.Bool("version", false, "Show version information")
@@ -40,6 +83,14 @@ func main() {
 	learnFlag := flag.Bool("learn", false, "Enter learning mode")
 	langFlag := flag.String("lang", "", "Set language (en, zh, zh-tw)")
 	countFlag := flag.Int("count", cfg.Practice.DefaultCount, "Number of questions for practice")
+	rowsFlag := flag.String("rows", strings.Join(cfg.Practice.Rows, ","), "Only practise these rows, e.g. ka,sa")
+	chartFlag := flag.Bool("chart", false, "Show the gojūon chart in learning mode")
+	originsFlag := flag.Bool("origins", false, "Show kana grouped by origin in learning mode")
+	voicingFlag := flag.Bool("voicing", false, "Show kana beside their dakuten and handakuten forms in learning mode")
+	deckFlag := flag.String("deck", cfg.Practice.Deck, "Study or practise a deck from the data directory")
+	wordsFlag := flag.Bool("words", false, "Practise reading example words instead of single characters")
+	choicesFlag := flag.Int("choices", cfg.Practice.Choices, "Offer this many romaji options per question")
+	drillFlag := flag.String("drill", "", "Practise a kana together with the kana that look like it")
 
 	flag.Parse()
 
@@ -54,6 +105,14 @@ func main() {
 		return
 	}
 
+	// Lookups given as arguments load the data themselves
+	if *practiseFlag || *learnFlag || len(flag.Args()) == 0 {
+		if err := loadData(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Update language if specified
 	if *langFlag != "" {
 		cfg.Language = *langFlag
@@ -73,48 +132,392 @@ func main() {
 		return
 	}
 
+	// Practice and learning modes show romaji in the configured system;
+	// the TUI and lookups check it themselves
+	system := goju.Hepburn
+	if *practiseFlag || *learnFlag {
+		if system, err = cfg.RomanizationSystem(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Handle specific modes
 	if *practiseFlag {
+		var rowNames []string
+		if *rowsFlag != "" {
+			rowNames = strings.Split(*rowsFlag, ",")
+		}
+		rows, err := config.ParseRows(rowNames)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		session := practise.NewPracticeSession(*countFlag, cfg.Practice.Categories)
+		session.System = system
+		session.Language = cfg.Language
+		session.Rows = rows
+		session.Deck = *deckFlag
+		session.Words = *wordsFlag
+		session.Choices = *choicesFlag
+		session.Drill = goju.Normalize(*drillFlag)
+		if _, ok := goju.GetDeck(session.Deck); session.Deck != "" && !ok {
+			fmt.Printf("Error: unknown deck %q\n", session.Deck)
+			os.Exit(1)
+		}
+		if err := session.Validate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
 		runPracticeSession(session)
 		return
 	}
 
 	if *learnFlag {
+		if *chartFlag {
+			fmt.Print(learn.FormatChart("hiragana", system))
+			return
+		}
+		if *originsFlag {
+			fmt.Print(learn.FormatOrigins())
+			return
+		}
+		if *voicingFlag {
+			fmt.Print(learn.FormatVoicing("hiragana", system))
+			return
+		}
 		content := learn.GetLearningContent(learn.Hard, "both")
+		if *deckFlag != "" {
+			var ok bool
+			if content, ok = learn.GetDeckContent(*deckFlag); !ok {
+				fmt.Printf("Error: unknown deck %q\n", *deckFlag)
+				os.Exit(1)
+			}
+		}
+		content.System = system
+		content.Language = cfg.Language
 		fmt.Println(learn.FormatLearningContent(content, "both"))
 		return
 	}
 
 	// Handle lookup mode
 	if len(flag.Args()) > 0 {
-		lookupType := flag.Args()[0]
-		values := flag.Args()[1:]
-		if len(values) == 0 {
-			fmt.Println("Please provide characters to look up")
+		if err := runLookup(flag.Args(), cfg); err != nil && !helpRequested(err) {
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
-
-		results := lookup.BatchLookup(lookupType, values)
-		fmt.Println(lookup.FormatBatchLookup(results))
 		return
 	}
 }
 
+// loadData merges custom characters and decks from the data directory into
+// the registry, then the user's own mnemonics over the built-in ones. Only
+// the commands that use them call it, once their arguments are parsed, so a
+// bad data file cannot break --help.
+func loadData() error {
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return fmt.Errorf("locating data directory: %w", err)
+	}
+	if err := goju.LoadDataDir(dataDir); err != nil {
+		return fmt.Errorf("loading character data: %w", err)
+	}
+	mnemonicsPath, err := config.GetMnemonicsPath()
+	if err != nil {
+		return fmt.Errorf("locating mnemonics: %w", err)
+	}
+	if err := goju.LoadMnemonics(mnemonicsPath); err != nil {
+		return fmt.Errorf("loading mnemonics: %w", err)
+	}
+	return nil
+}
+
+// helpRequested reports whether err only means that a subcommand was run
+// with -h or --help, whose usage its flag set has already printed
+func helpRequested(err error) bool {
+	return errors.Is(err, flag.ErrHelp)
+}
+
+// runLookup looks up characters:
+// goju lookup [--detail] [--sort=false] [--format text|json|yaml|csv|tsv] [type] <values...>
+// Without a type, lookup.default_input_type from the configuration is used,
+// which detects the type of each value unless set otherwise, and
+// lookup.show_detail sets whether --detail is on by default.
+func runLookup(args []string, cfg *config.Config) error {
+	flags := flag.NewFlagSet("lookup", flag.ContinueOnError)
+	detail := flags.Bool("detail", cfg.Lookup.ShowDetail, "Show every detail, such as romanizations, code points and mnemonics")
+	format := flags.String("format", string(lookup.Text), "Output format (text, json, yaml, csv, tsv)")
+	sorted := flags.Bool("sort", true, "List the values in dictionary order; --sort=false keeps the order given")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("please provide characters to look up")
+	}
+	if err := loadData(); err != nil {
+		return err
+	}
+	system, err := cfg.RomanizationSystem()
+	if err != nil {
+		return err
+	}
+	formatter, err := lookup.NewFormatter(lookup.OutputFormat(strings.ToLower(*format)), cfg.Language, *detail)
+	if err != nil {
+		return err
+	}
+
+	inputType, values := cfg.Lookup.DefaultInputType, flags.Args()
+	if lookup.IsInputType(flags.Arg(0)) {
+		inputType, values = flags.Arg(0), flags.Args()[1:]
+	}
+	if !lookup.IsInputType(inputType) {
+		return fmt.Errorf("unknown input type %q in lookup.default_input_type (want detect, hiragana, katakana or romaji)", inputType)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("please provide characters to look up")
+	}
+
+	results := lookup.BatchLookupIn(inputType, values, system)
+	if *sorted {
+		lookup.SortResults(results, goju.Collator{})
+	}
+	if strings.EqualFold(*format, string(lookup.Text)) {
+		for _, value := range values {
+			tokens := []lookup.Token{{Text: value, Type: strings.ToLower(inputType)}}
+			if strings.EqualFold(inputType, lookup.Detect) {
+				tokens = lookup.Tokenize(value)
+			}
+			for _, token := range tokens {
+				if token.Type != lookup.Romaji {
+					continue
+				}
+				if parses := lookup.FormatParses(token.Text); parses != "" {
+					fmt.Printf("Readings of %s; showing the first\n\n", parses)
+				}
+			}
+		}
+	}
+	output, err := formatter.Format(results)
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+	return nil
+}
+
+// runMnemonic shows or sets the user's own mnemonic for a character:
+// goju mnemonic [--lang zh] [--reset] <kana> [text...]
+func runMnemonic(args []string, cfg *config.Config) error {
+	flags := flag.NewFlagSet("mnemonic", flag.ContinueOnError)
+	lang := flags.String("lang", cfg.Language, "Language of the mnemonic (en, zh, zh-tw)")
+	reset := flags.Bool("reset", false, "Remove your mnemonic and use the built-in one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("please provide a character")
+	}
+	if err := loadData(); err != nil {
+		return err
+	}
+	path, err := config.GetMnemonicsPath()
+	if err != nil {
+		return err
+	}
+
+	char, ok := goju.GetCharacterByID(flags.Arg(0))
+	if !ok {
+		if char, ok = goju.DefaultRegistry().ByKana(goju.Normalize(flags.Arg(0))); !ok {
+			return fmt.Errorf("unknown character %q", flags.Arg(0))
+		}
+	}
+
+	text := strings.Join(flags.Args()[1:], " ")
+	if text != "" && *reset {
+		return fmt.Errorf("--reset takes no mnemonic text")
+	}
+	if text == "" && !*reset {
+		mnemonic, ok := goju.GetMnemonic(char.ID)
+		if !ok {
+			fmt.Printf("No mnemonic for %s yet\n", char.Hiragana)
+			return nil
+		}
+		fmt.Printf("%s: %s\n", char.Hiragana, mnemonic.Text(*lang))
+		return nil
+	}
+
+	goju.SetMnemonic(char.ID, *lang, text)
+	if err := goju.SaveMnemonics(path); err != nil {
+		return err
+	}
+	if *reset {
+		fmt.Printf("Removed your %s mnemonic for %s\n", *lang, char.Hiragana)
+	} else {
+		fmt.Printf("Saved your %s mnemonic for %s\n", *lang, char.Hiragana)
+	}
+	return nil
+}
+
+// runConvert converts text from in to the target script line by line, so
+// it can sit in the middle of a pipeline
+func runConvert(args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	to := flags.String("to", "katakana", "Target script (hiragana, katakana, halfwidth)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if _, ok := goju.ConvertScript("", *to); !ok {
+		return fmt.Errorf("unknown target %q (want hiragana, katakana or halfwidth)", *to)
+	}
+
+	reader := bufio.NewReader(in)
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+	for {
+		line, err := reader.ReadString('\n')
+		converted, _ := goju.ConvertScript(line, *to)
+		if _, werr := writer.WriteString(converted); werr != nil {
+			return werr
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// runStrokes draws the stroke order of each kana given. On a terminal the
+// strokes are animated; otherwise, or with --static, one frame is printed
+// per stroke.
+func runStrokes(args []string, out *os.File) error {
+	flags := flag.NewFlagSet("strokes", flag.ContinueOnError)
+	mode := flags.String("canvas", string(strokes.Braille), "Canvas to draw on (braille, block)")
+	size := flags.Int("size", 32, "Canvas size in dots")
+	delay := flags.Duration("delay", 80*time.Millisecond, "Pause between segments when animating")
+	static := flags.Bool("static", false, "Print one frame per stroke instead of animating")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("please provide kana to draw")
+	}
+
+	canvas, err := strokes.NewCanvas(strokes.Mode(*mode), *size)
+	if err != nil {
+		return err
+	}
+	if info, err := out.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		*static = true
+	}
+
+	for _, arg := range flags.Args() {
+		for _, r := range goju.Normalize(arg) {
+			kana := string(r)
+			kanaStrokes, ok := goju.GetStrokes(kana)
+			if !ok {
+				return fmt.Errorf("no stroke data for %s", kana)
+			}
+			if !*static {
+				if err := strokes.Animate(out, canvas, kana, kanaStrokes, *delay); err != nil {
+					return err
+				}
+				continue
+			}
+			for i, frame := range strokes.StrokeFrames(canvas, kanaStrokes) {
+				fmt.Fprintf(out, "%s  stroke %d/%d\n%s", kana, i+1, len(kanaStrokes), frame)
+			}
+		}
+	}
+	return nil
+}
+
+// runSpeak renders kana to a WAV file: goju speak <kana...> [-o out.wav].
+// Flags may come before or after the kana; several arguments are spoken
+// with a pause between them.
+func runSpeak(args []string) error {
+	synth := speech.NewSynthesizer()
+	flags := flag.NewFlagSet("speak", flag.ContinueOnError)
+	output := flags.String("o", "goju.wav", "WAV file to write")
+	flags.Float64Var(&synth.Pitch, "pitch", synth.Pitch, "Starting pitch of the voice in Hz")
+	flags.DurationVar(&synth.MoraLength, "mora", synth.MoraLength, "Length of one mora")
+
+	var text []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		text = append(text, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if len(text) == 0 {
+		return fmt.Errorf("please provide kana to speak")
+	}
+	if synth.Pitch <= 0 || synth.MoraLength <= 0 {
+		return fmt.Errorf("pitch and mora length must be positive")
+	}
+
+	samples, err := synth.Synthesize(strings.Join(text, " "))
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	if err := speech.WriteWAV(writer, samples, synth.SampleRate); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	length := time.Duration(len(samples)) * time.Second / time.Duration(synth.SampleRate)
+	fmt.Printf("Wrote %s (%.1fs)\n", *output, length.Seconds())
+	return nil
+}
+
 func runPracticeSession(session *practise.PracticeSession) {
 	fmt.Println("Starting practice session...")
-	fmt.Println("Type 'quit' to exit")
+	fmt.Println("Type '?' for a hint or 'quit' to exit")
 
 	for i := 0; i < session.Count; i++ {
-		char := session.GetNextCharacter()
-		session.Current.Character = char
-		session.Current.StartTime = time.Now()
+		session.NextQuestion()
 
-		fmt.Printf("\nQuestion %d/%d: What is the romaji for: %s\n", i+1, session.Count, char.Hiragana)
-		fmt.Print("Answer: ")
+		fmt.Printf("\nQuestion %d/%d: What is the romaji for: %s\n", i+1, session.Count, session.Prompt())
+		for n, option := range session.Current.Options {
+			fmt.Printf("  %d) %s\n", n+1, option)
+		}
 
+		// "?" shows the mnemonic and asks again
 		var answer string
-		fmt.Scanln(&answer)
+		for {
+			fmt.Print("Answer: ")
+			answer = ""
+			fmt.Scanln(&answer)
+			if answer != "?" {
+				break
+			}
+			if hint := session.Hint(); hint != "" {
+				fmt.Printf("Hint: %s\n", hint)
+			} else {
+				fmt.Println("No hint for this question")
+			}
+		}
 
 		if answer == "quit" {
 			fmt.Println("\nPractice session ended")
@@ -124,7 +527,13 @@ func runPracticeSession(session *practise.PracticeSession) {
 		correct := session.CheckAnswer(answer)
 		if !correct {
 			session.RecordMistake(answer)
-			fmt.Printf("Incorrect! The answer is: %s\n", char.Romaji)
+			fmt.Printf("Incorrect! The answer is: %s\n", session.Answer())
+			if confusable, ok := session.Confusion(answer); ok {
+				fmt.Printf("%s is %s, which is easily confused with %s\n", confusable.Kana(), confusable.Character.RomajiIn(session.System), session.Prompt())
+			}
+			if hint := session.Hint(); hint != "" {
+				fmt.Printf("Remember: %s\n", hint)
+			}
 			fmt.Println("Press Enter to continue...")
 			fmt.Scanln()
 		} else {
@@ -138,7 +547,11 @@ func runPracticeSession(session *practise.PracticeSession) {
 	if len(session.GetWeaknesses(5)) > 0 {
 		fmt.Println("\nWeaknesses:")
 		for _, weakness := range session.GetWeaknesses(5) {
-			fmt.Printf("- %s (missed %d times)\n", weakness.Character.Romaji, weakness.Attempts)
+			missed := weakness.Character.RomajiIn(session.System)
+			if weakness.Word.Kana != "" {
+				missed = fmt.Sprintf("%s (%s)", weakness.Word.Kana, weakness.Word.RomajiIn(session.System))
+			}
+			fmt.Printf("- %s (missed %d times)\n", missed, weakness.Attempts)
 		}
 	}
 }
@@ -149,9 +562,14 @@ func printHelp() {
 	fmt.Println("\nUsage:")
 	fmt.Println("  goju [command] [options]")
 	fmt.Println("\nCommands:")
+	fmt.Println("  lookup      Look up characters (--detail for everything known, --format json|yaml|csv|tsv)")
+	fmt.Println("  mnemonic    Show or set your own mnemonic for a character")
 	fmt.Println("  hiragana    Look up hiragana characters")
 	fmt.Println("  katakana    Look up katakana characters")
 	fmt.Println("  romaji      Look up romaji")
+	fmt.Println("  convert     Convert stdin to another script (--to hiragana|katakana|halfwidth)")
+	fmt.Println("  strokes     Draw the stroke order of kana (--canvas braille|block, --static)")
+	fmt.Println("  speak       Say kana into a WAV file (-o out.wav, --pitch, --mora)")
 	fmt.Println("\nOptions:")
 	fmt.Println("  -h, --help     Show this help message")
 	fmt.Println("  -v, --version  Show version information")
@@ -159,9 +577,29 @@ func printHelp() {
 	fmt.Println("  -l, --learn    Enter learning mode")
 	fmt.Println("  --lang         Set language (en, zh, zh-tw)")
 	fmt.Println("  --count        Number of questions for practice")
+	fmt.Println("  --rows         Only practise these rows, e.g. ka,sa")
+	fmt.Println("  --chart        Show the gojūon chart in learning mode")
+	fmt.Println("  --origins      Show kana grouped by origin in learning mode")
+	fmt.Println("  --voicing      Show the dakuten and handakuten table in learning mode")
+	fmt.Println("  --deck         Study or practise a deck from the data directory")
+	fmt.Println("  --words        Practise reading example words")
+	fmt.Println("  --choices      Offer this many romaji options per question")
+	fmt.Println("  --drill        Practise a kana with the kana that look like it")
 	fmt.Println("\nExamples:")
 	fmt.Println("  goju                    # Launch TUI")
 	fmt.Println("  goju hiragana あ        # Look up hiragana")
+	fmt.Println("  goju lookup --detail hiragana あ")
+	fmt.Println("  goju lookup --format json romaji aiu")
+	fmt.Println("  goju lookup かタna         # Detect the script of each part")
+	fmt.Println("  goju mnemonic あ \"An apple with a cross\"")
 	fmt.Println("  goju --practise         # Enter practice mode")
+	fmt.Println("  goju --practise --words # Read whole words")
+	fmt.Println("  goju --practise --drill シ --choices 4")
 	fmt.Println("  goju --learn            # Enter learning mode")
+	fmt.Println("  goju --learn --chart    # Show the gojūon chart")
+	fmt.Println("  goju --learn --origins  # Show kana grouped by origin")
+	fmt.Println("  goju --learn --voicing  # Show か → が, は → ば → ぱ")
+	fmt.Println("  echo ひらがな | goju convert --to katakana")
+	fmt.Println("  goju strokes あ         # Watch how あ is written")
+	fmt.Println("  goju speak あいう -o out.wav")
 }
@@ -1,40 +1,79 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 
+	"github.com/make17better/goju/pkg/goju"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Language string `yaml:"language"`
-	Theme    string `yaml:"theme"`
-	History  struct {
+	Language     string `yaml:"language"`
+	Theme        string `yaml:"theme"`
+	Romanization string `yaml:"romanization"`
+	History      struct {
 		Enabled bool `yaml:"enabled"`
 		Limit   int  `yaml:"limit"`
 	} `yaml:"history"`
 	Practice struct {
 		DefaultCount int      `yaml:"default_count"`
 		Categories   []string `yaml:"categories"`
+		Rows         []string `yaml:"rows,omitempty"`
+		Deck         string   `yaml:"deck,omitempty"`
+		Choices      int      `yaml:"choices,omitempty"` // romaji options per question, typed answers when below 2
 	} `yaml:"practice"`
+	Lookup struct {
+		ShowDetail       bool   `yaml:"show_detail"`
+		DefaultInputType string `yaml:"default_input_type"`
+	} `yaml:"lookup"`
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	cfg := &Config{
-		Language: "en",
-		Theme:    "default",
+		Language:     "en",
+		Theme:        "default",
+		Romanization: "hepburn",
 	}
 	cfg.History.Enabled = true
 	cfg.History.Limit = 100
 	cfg.Practice.DefaultCount = 10
-	cfg.Practice.Categories = []string{"seion", "dakuon", "handaku", "yoon"}
+	cfg.Practice.Categories = []string{"seion", "dakuon", "handaku", "yoon", "gairaigo"}
+	cfg.Lookup.DefaultInputType = "detect"
 	return cfg
 }
 
+// RomanizationSystem returns the configured romanization system
+func (c *Config) RomanizationSystem() (goju.RomanizationSystem, error) {
+	system, err := goju.ParseRomanizationSystem(c.Romanization)
+	if err != nil {
+		return "", fmt.Errorf("%w in romanization (want hepburn, kunrei or nihon)", err)
+	}
+	return system, nil
+}
+
+// PracticeRows returns the configured practice rows
+func (c *Config) PracticeRows() ([]goju.Row, error) {
+	return ParseRows(c.Practice.Rows)
+}
+
+// ParseRows parses gojūon row names such as "ka" and "sa"
+func ParseRows(names []string) ([]goju.Row, error) {
+	var rows []goju.Row
+	for _, name := range names {
+		row, ok := goju.ParseRow(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown row %q", name)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 // GetConfigDir returns the configuration directory path
 func GetConfigDir() (string, error) {
 	var configDir string
@@ -108,6 +147,24 @@ func GetHistoryPath() (string, error) {
 	return filepath.Join(configDir, "history.yaml"), nil
 }
 
+// GetDataDir returns the directory holding custom character and deck files
+func GetDataDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "data"), nil
+}
+
+// GetMnemonicsPath returns the path to the user's own mnemonics
+func GetMnemonicsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "mnemonics.yaml"), nil
+}
+
 // GetLogPath returns the path to the log file
 func GetLogPath() (string, error) {
 	configDir, err := GetConfigDir()
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+func TestParseRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []goju.Row
+		wantErr bool
+	}{
+		{"Seion rows", []string{"ka", "sa"}, []goju.Row{goju.RowKa, goju.RowSa}, false},
+		{"Voiced row", []string{"ga"}, []goju.Row{goju.RowGa}, false},
+		{"Row of ん", []string{"n"}, []goju.Row{goju.RowN}, false},
+		{"Case and suffix", []string{" KA-row "}, []goju.Row{goju.RowKa}, false},
+		{"No rows", nil, nil, false},
+		{"Unknown row", []string{"ka", "xa"}, nil, true},
+		{"Kana instead of row", []string{"か"}, nil, true},
+		{"Empty name", []string{""}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRows(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
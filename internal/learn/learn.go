@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/make17better/goju/pkg/goju"
+	"github.com/mattn/go-runewidth"
 )
 
 // Difficulty represents the learning difficulty level
@@ -21,6 +22,10 @@ type LearningContent struct {
 	Title      string
 	Categories []string
 	Characters []goju.Character
+	System     goju.RomanizationSystem
+	Language   string        // language of example word meanings, English when empty
+	Collator   goju.Collator // order of the characters in each category, dictionary order by default
+	KeepOrder  bool          // list the characters in the order given, as a deck does, instead of sorting them
 }
 
 // GetLearningContent returns learning content based on difficulty and script type
@@ -37,7 +42,9 @@ func GetLearningContent(difficulty Difficulty, scriptType string) LearningConten
 		categories = []string{string(goju.Seion), string(goju.Dakuon)}
 		content.Title = "Basic and Voiced Sounds (清音・浊音)"
 	case Hard:
-		categories = []string{string(goju.Seion), string(goju.Dakuon), string(goju.Handaku), string(goju.Yoon)}
+		for _, category := range goju.GetCategories() {
+			categories = append(categories, string(category))
+		}
 		content.Title = "All Sounds (五十音)"
 	}
 
@@ -45,16 +52,42 @@ func GetLearningContent(difficulty Difficulty, scriptType string) LearningConten
 
 	// Collect characters based on categories and script type
 	for _, category := range categories {
-		if chars, ok := goju.Characters[goju.Category(category)]; ok {
-			content.Characters = append(content.Characters, chars...)
-		}
+		chars := goju.GetCharactersByCategory(goju.Category(category))
+		content.Characters = append(content.Characters, chars...)
 	}
 
 	return content
 }
 
-// FormatCharacter formats a character for display
-func FormatCharacter(char goju.Character, scriptType string) string {
+// GetDeckContent returns learning content for a deck from the custom data files
+func GetDeckContent(name string) (LearningContent, bool) {
+	chars, ok := goju.GetDeck(name)
+	if !ok {
+		return LearningContent{}, false
+	}
+
+	content := LearningContent{
+		Title:      fmt.Sprintf("Deck: %s", name),
+		Characters: chars,
+		KeepOrder:  true,
+	}
+	for _, char := range chars {
+		known := false
+		for _, category := range content.Categories {
+			if category == string(char.Category) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			content.Categories = append(content.Categories, string(char.Category))
+		}
+	}
+	return content, true
+}
+
+// FormatCharacter formats a character for display using the given romanization system
+func FormatCharacter(char goju.Character, scriptType string, system goju.RomanizationSystem) string {
 	var display string
 	switch strings.ToLower(scriptType) {
 	case "hiragana":
@@ -67,7 +100,11 @@ func FormatCharacter(char goju.Character, scriptType string) string {
 		display = char.Hiragana
 	}
 
-	return fmt.Sprintf("%s - %s", display, char.Romaji)
+	romaji := char.RomajiIn(system)
+	if romaji == "" {
+		return display
+	}
+	return fmt.Sprintf("%s - %s", display, romaji)
 }
 
 // FormatCategory formats a category for display
@@ -81,11 +118,49 @@ func FormatCategory(category string) string {
 		return "半浊音 (Half-Voiced Sounds)"
 	case string(goju.Yoon):
 		return "拗音 (Contracted Sounds)"
+	case string(goju.Gairaigo):
+		return "外来语音 (Loanword Sounds)"
+	case string(goju.Modifier):
+		return "小写假名与符号 (Small Kana and Marks)"
 	default:
 		return category
 	}
 }
 
+// formatOrigin returns the source characters of the scripts being shown,
+// written once when both scripts share the same source
+func formatOrigin(char goju.Character, scriptType string) string {
+	switch strings.ToLower(scriptType) {
+	case "katakana":
+		return char.KatakanaOrigin
+	case "both":
+		if char.HiraganaOrigin == char.KatakanaOrigin || char.KatakanaOrigin == "" {
+			return char.HiraganaOrigin
+		}
+		if char.HiraganaOrigin == "" {
+			return char.KatakanaOrigin
+		}
+		return fmt.Sprintf("%s / %s", char.HiraganaOrigin, char.KatakanaOrigin)
+	default:
+		return char.HiraganaOrigin
+	}
+}
+
+// formatExample returns the first example word spelled with a character,
+// such as "あさ 朝 (asa) morning"
+func formatExample(char goju.Character, content LearningContent) string {
+	words := goju.GetWordsFor(char)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0].Format(content.System, content.Language)
+}
+
+// categoryIntros explains categories whose characters do not stand alone
+var categoryIntros = map[string]string{
+	string(goju.Modifier): "These characters are not read on their own; each one changes how the kana next to it is read.",
+}
+
 // FormatLearningContent formats the learning content for display
 func FormatLearningContent(content LearningContent, scriptType string) string {
 	var sb strings.Builder
@@ -102,9 +177,27 @@ func FormatLearningContent(content LearningContent, scriptType string) string {
 	// Format each category
 	for _, category := range content.Categories {
 		sb.WriteString(fmt.Sprintf("%s:\n", FormatCategory(category)))
+		if intro, ok := categoryIntros[category]; ok {
+			sb.WriteString(fmt.Sprintf("  %s\n", intro))
+		}
 		if chars, ok := categoryChars[category]; ok {
+			if !content.KeepOrder {
+				content.Collator.SortCharacters(chars)
+			}
 			for _, char := range chars {
-				sb.WriteString(fmt.Sprintf("  %s\n", FormatCharacter(char, scriptType)))
+				sb.WriteString(fmt.Sprintf("  %s\n", FormatCharacter(char, scriptType, content.System)))
+				if origin := formatOrigin(char, scriptType); origin != "" {
+					sb.WriteString(fmt.Sprintf("    Origin: %s\n", origin))
+				}
+				if char.Description != "" {
+					sb.WriteString(fmt.Sprintf("    %s\n", char.Description))
+				}
+				if char.Notes != "" {
+					sb.WriteString(fmt.Sprintf("    %s\n", char.Notes))
+				}
+				if example := formatExample(char, content); example != "" {
+					sb.WriteString(fmt.Sprintf("    e.g. %s\n", example))
+				}
 			}
 		}
 		sb.WriteString("\n")
@@ -112,3 +205,103 @@ func FormatLearningContent(content LearningContent, scriptType string) string {
 
 	return sb.String()
 }
+
+// FormatGrid formats a gojūon grid as a chart with one row per line.
+// Gaps in the table are shown as a dash.
+func FormatGrid(grid goju.Grid, scriptType string, system goju.RomanizationSystem) string {
+	var sb strings.Builder
+
+	header := "     "
+	for _, column := range grid.Columns {
+		header += pad(string(column), cellWidth)
+	}
+	sb.WriteString(strings.TrimRight(header, " ") + "\n")
+
+	for i, row := range grid.Rows {
+		line := pad(string(row), 5)
+		for _, cell := range grid.Cells[i] {
+			line += formatCell(cell, scriptType, system)
+		}
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// cellWidth is the number of terminal columns each kana and its romaji take
+// in the chart and voicing tables
+const cellWidth = 9
+
+// formatCell writes a kana and its romaji padded to cellWidth, or a dash
+// for a gap in the table
+func formatCell(char *goju.Character, scriptType string, system goju.RomanizationSystem) string {
+	if char == nil {
+		return pad("-", cellWidth)
+	}
+	display := char.Hiragana
+	if strings.ToLower(scriptType) == "katakana" {
+		display = char.Katakana
+	}
+	return pad(fmt.Sprintf("%s %s", display, char.RomajiIn(system)), cellWidth)
+}
+
+// pad fills s with spaces to width terminal columns. Kana and the marks ゛
+// and ゜ are full-width, so each takes two columns.
+func pad(s string, width int) string {
+	if n := width - runewidth.StringWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// FormatChart formats the gojūon and voiced tables for display, followed by ん
+func FormatChart(scriptType string, system goju.RomanizationSystem) string {
+	var sb strings.Builder
+
+	sb.WriteString("五十音图 (Gojūon Chart)\n\n")
+	sb.WriteString(FormatGrid(goju.GojuonGrid(), scriptType, system))
+	for _, char := range goju.GetCharactersByRow(goju.RowN) {
+		sb.WriteString(fmt.Sprintf("%-5s%s\n", goju.RowN, FormatCharacter(char, scriptType, system)))
+	}
+
+	sb.WriteString("\n浊音・半浊音 (Voiced and Half-Voiced Sounds)\n\n")
+	sb.WriteString(FormatGrid(goju.VoicedGrid(), scriptType, system))
+
+	return sb.String()
+}
+
+// FormatVoicing lists each kana that takes dakuten or handakuten beside
+// its voiced forms, plain kana first
+func FormatVoicing(scriptType string, system goju.RomanizationSystem) string {
+	var sb strings.Builder
+
+	sb.WriteString("清音・浊音・半浊音 (Voicing)\n\n")
+	sb.WriteString("  " + pad("Plain", cellWidth) + pad("゛", cellWidth) + "゜\n")
+	for _, set := range goju.GetVoicingTable() {
+		base := set.Base
+		line := "  " + formatCell(&base, scriptType, system) + formatCell(set.Voiced, scriptType, system) + formatCell(set.SemiVoiced, scriptType, system)
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// FormatOrigins lists the basic kana grouped by the man'yōgana they
+// developed from, hiragana first
+func FormatOrigins() string {
+	var sb strings.Builder
+
+	sb.WriteString("字源 (Kana Origins)\n\n")
+	for _, group := range goju.GetOriginGroups() {
+		kana := make([]string, 0, len(group.Hiragana)+len(group.Katakana))
+		for _, char := range group.Hiragana {
+			kana = append(kana, char.Hiragana)
+		}
+		for _, char := range group.Katakana {
+			kana = append(kana, char.Katakana)
+		}
+		sb.WriteString(fmt.Sprintf("  %s  %s\n", group.Origin, strings.Join(kana, " ")))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,215 @@
+package learn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/make17better/goju/pkg/goju"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestFormatGrid(t *testing.T) {
+	tests := []struct {
+		name       string
+		grid       goju.Grid
+		scriptType string
+		system     goju.RomanizationSystem
+		wantLines  []string
+	}{
+		{
+			name:       "Header",
+			grid:       goju.GojuonGrid(),
+			scriptType: "hiragana",
+			system:     goju.Hepburn,
+			wantLines:  []string{"     a        i        u        e        o"},
+		},
+		{
+			name:       "Hiragana row",
+			grid:       goju.GojuonGrid(),
+			scriptType: "hiragana",
+			system:     goju.Hepburn,
+			wantLines:  []string{"ka   か ka    き ki    く ku    け ke    こ ko"},
+		},
+		{
+			name:       "Katakana row",
+			grid:       goju.GojuonGrid(),
+			scriptType: "katakana",
+			system:     goju.Hepburn,
+			wantLines:  []string{"ta   タ ta    チ chi   ツ tsu   テ te    ト to"},
+		},
+		{
+			name:       "Kunrei spellings",
+			grid:       goju.GojuonGrid(),
+			scriptType: "hiragana",
+			system:     goju.Kunrei,
+			wantLines:  []string{"ta   た ta    ち ti    つ tu    て te    と to"},
+		},
+		{
+			name:       "Gaps shown as dashes",
+			grid:       goju.GojuonGrid(),
+			scriptType: "hiragana",
+			system:     goju.Hepburn,
+			wantLines: []string{
+				"ya   や ya    -        ゆ yu    -        よ yo",
+				"wa   わ wa    -        -        -        を wo",
+			},
+		},
+		{
+			name:       "Voiced rows",
+			grid:       goju.VoicedGrid(),
+			scriptType: "hiragana",
+			system:     goju.Hepburn,
+			wantLines: []string{
+				"da   だ da    ぢ ji    づ zu    で de    ど do",
+				"pa   ぱ pa    ぴ pi    ぷ pu    ぺ pe    ぽ po",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatGrid(tt.grid, tt.scriptType, tt.system)
+			lines := strings.Split(got, "\n")
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("FormatGrid() has no line %q in:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatChart(t *testing.T) {
+	tests := []struct {
+		name       string
+		scriptType string
+		wantLines  []string
+	}{
+		{
+			name:       "Hiragana",
+			scriptType: "hiragana",
+			wantLines: []string{
+				"五十音图 (Gojūon Chart)",
+				"a    あ a     い i     う u     え e     お o",
+				"n    ん - n",
+				"浊音・半浊音 (Voiced and Half-Voiced Sounds)",
+				"ga   が ga    ぎ gi    ぐ gu    げ ge    ご go",
+			},
+		},
+		{
+			name:       "Katakana",
+			scriptType: "katakana",
+			wantLines: []string{
+				"sa   サ sa    シ shi   ス su    セ se    ソ so",
+				"n    ン - n",
+				"ba   バ ba    ビ bi    ブ bu    ベ be    ボ bo",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatChart(tt.scriptType, goju.Hepburn)
+			lines := strings.Split(got, "\n")
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("FormatChart() has no line %q in:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+// containsLine reports whether lines holds want exactly
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFormatOrigins(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Title", "字源 (Kana Origins)"},
+		{"Hiragana only", "  安  あ"},
+		{"Katakana only", "  阿  ア"},
+		{"Shared origin, hiragana first", "  久  く ク"},
+		{"Katakana from another kanji", "  散  サ"},
+	}
+
+	lines := strings.Split(FormatOrigins(), "\n")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !containsLine(lines, tt.want) {
+				t.Errorf("FormatOrigins() has no line %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatVoicing(t *testing.T) {
+	// Each cell starts at the same terminal column on every line, header
+	// included, however many full-width characters come before it
+	tests := []struct {
+		name       string
+		scriptType string
+		cell       string
+		column     int
+	}{
+		{"Plain header", "hiragana", "Plain", 2},
+		{"Dakuten header", "hiragana", "゛", 11},
+		{"Handakuten header", "hiragana", "゜", 20},
+		{"Plain kana", "hiragana", "は ha", 2},
+		{"Voiced kana", "hiragana", "ば ba", 11},
+		{"Half-voiced kana", "hiragana", "ぱ pa", 20},
+		{"Voiced yoon", "hiragana", "びゃ bya", 11},
+		{"Half-voiced yoon", "hiragana", "ぴゃ pya", 20},
+		{"Katakana", "katakana", "ポ po", 20},
+	}
+
+	lines := map[string][]string{}
+	for _, scriptType := range []string{"hiragana", "katakana"} {
+		lines[scriptType] = strings.Split(FormatVoicing(scriptType, goju.Hepburn), "\n")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, line := range lines[tt.scriptType] {
+				if i := strings.Index(line, tt.cell); i >= 0 {
+					if got := runewidth.StringWidth(line[:i]); got != tt.column {
+						t.Errorf("%q starts at column %d, want %d in line %q", tt.cell, got, tt.column, line)
+					}
+					return
+				}
+			}
+			t.Errorf("FormatVoicing() has no cell %q", tt.cell)
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"Latin", "ka", 5, "ka   "},
+		{"Kana take two columns", "か ka", 9, "か ka    "},
+		{"Full-width dakuten", "゛", 9, "゛       "},
+		{"Yoon", "きゃ kya", 9, "きゃ kya "},
+		{"Already wide enough", "きゃ kya", 4, "きゃ kya"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pad(tt.s, tt.width); got != tt.want {
+				t.Errorf("pad(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package lookup
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+// Input types a value can be looked up as. Detect works out the type of
+// each value, and of each part of a mixed value such as かタna, from the
+// Unicode block of its characters.
+const (
+	Hiragana = "hiragana"
+	Katakana = "katakana"
+	Romaji   = "romaji"
+	Detect   = "detect"
+	Unknown  = "unknown" // detected text that is neither kana nor romaji
+)
+
+// IsInputType reports whether name is an input type lookup accepts
+func IsInputType(name string) bool {
+	switch strings.ToLower(name) {
+	case Hiragana, Katakana, Romaji, Detect:
+		return true
+	}
+	return false
+}
+
+// Token is a run of input written in one script
+type Token struct {
+	Text  string
+	Type  string // Hiragana, Katakana, Romaji or Unknown
+	Start int    // byte offset of Text in the normalized value
+}
+
+// Tokenize normalizes a value and splits it into runs of one script.
+// The long vowel mark ー joins the run before it, so らーめん stays one
+// hiragana run, and spaces and ・ separate runs.
+func Tokenize(value string) []Token {
+	var tokens []Token
+	for i, r := range goju.Normalize(value) {
+		if unicode.IsSpace(r) || r == '・' {
+			tokens = append(tokens, Token{})
+			continue
+		}
+		kind := runeType(r)
+		last := len(tokens) - 1
+		if last >= 0 && tokens[last].Text != "" && (tokens[last].Type == kind || r == 'ー' && tokens[last].Type != Romaji) {
+			tokens[last].Text += string(r)
+			continue
+		}
+		tokens = append(tokens, Token{Text: string(r), Type: kind, Start: i})
+	}
+
+	// Drop the empty tokens left by separators
+	runs := tokens[:0]
+	for _, token := range tokens {
+		if token.Text != "" {
+			runs = append(runs, token)
+		}
+	}
+	return runs
+}
+
+// runeType classifies a rune by its Unicode block
+func runeType(r rune) string {
+	switch {
+	case r >= 0x3040 && r <= 0x309F:
+		return Hiragana
+	case r >= 0x30A0 && r <= 0x30FF, r >= 0x31F0 && r <= 0x31FF:
+		return Katakana
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '\'', r == '-':
+		return Romaji
+	}
+	return Unknown
+}
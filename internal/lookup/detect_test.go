@@ -0,0 +1,89 @@
+package lookup
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []Token
+	}{
+		{"あ", []Token{{"あ", Hiragana, 0}}},
+		{"かタna", []Token{{"か", Hiragana, 0}, {"タ", Katakana, 3}, {"na", Romaji, 6}}},
+		{"らーめん", []Token{{"らーめん", Hiragana, 0}}},                  // ー joins the run before it
+		{"ra-men", []Token{{"ra-men", Romaji, 0}}},                // - is romaji for ー
+		{"ｶﾞｯｺｳ", []Token{{"ガッコウ", Katakana, 0}}},                 // half-width is widened
+		{"ねこ 猫", []Token{{"ねこ", Hiragana, 0}, {"猫", Unknown, 7}}}, // spaces separate runs
+		{"ジョン・スミス", []Token{{"ジョン", Katakana, 0}, {"スミス", Katakana, 12}}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := Tokenize(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantTypes []string
+		wantFound []bool
+		wantParts []string // token@offset of each result
+	}{
+		{"Hiragana", "あ", []string{Hiragana}, []bool{true}, []string{"あ@0"}},
+		{"Katakana word", "ラーメン", []string{Katakana}, []bool{true}, []string{"ラーメン@0"}},
+		{"Romaji word", "aiu", []string{Romaji, Romaji, Romaji}, []bool{true, true, true}, []string{"a@0", "i@1", "u@2"}},
+		{"Mixed", "かタna", []string{Hiragana, Katakana, Romaji}, []bool{true, true, true}, []string{"か@0", "タ@3", "na@6"}},
+		{"Mixed with romaji units", " かkitte", []string{Hiragana, Romaji, Romaji, Romaji}, []bool{true, true, true, true}, []string{"か@0", "ki@3", "t@5", "te@6"}},
+		{"Kanji", "猫", []string{Unknown}, []bool{false}, []string{"猫@0"}},
+		{"Empty", "", []string{Unknown}, []bool{false}, []string{"@0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := BatchLookup(Detect, []string{tt.value})
+			var types, parts []string
+			var found []bool
+			for _, result := range results {
+				types = append(types, result.InputType)
+				found = append(found, result.Found)
+				parts = append(parts, fmt.Sprintf("%s@%d", result.Token, result.Offset))
+				if !result.Detected || result.Input != tt.value {
+					t.Errorf("result %+v should be detected from %q", result, tt.value)
+				}
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) || !reflect.DeepEqual(found, tt.wantFound) {
+				t.Errorf("BatchLookup(detect, %q) types %v found %v, want %v %v", tt.value, types, found, tt.wantTypes, tt.wantFound)
+			}
+			if !reflect.DeepEqual(parts, tt.wantParts) {
+				t.Errorf("BatchLookup(detect, %q) parts %v, want %v", tt.value, parts, tt.wantParts)
+			}
+		})
+	}
+}
+
+func TestLookupDetect(t *testing.T) {
+	result := Lookup(Detect, "ア")
+	if !result.Found || result.InputType != Katakana || !result.Detected || result.Character.Romaji != "a" {
+		t.Errorf("Lookup(detect, ア) = %+v, want a detected katakana あ", result)
+	}
+	if got, want := FormatLookupResult(result)[:len("Detected katakana: ア\n")], "Detected katakana: ア\n"; got != want {
+		t.Errorf("FormatLookupResult() starts %q, want %q", got, want)
+	}
+
+	// Mixed scripts are only split by BatchLookup
+	if result := Lookup(Detect, "かタ"); result.Found {
+		t.Errorf("Lookup(detect, かタ) = %+v, want not found", result)
+	}
+	if !IsInputType("Detect") || IsInputType("kanji") {
+		t.Error("IsInputType() should accept detect and reject kanji")
+	}
+}
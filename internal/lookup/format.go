@@ -0,0 +1,215 @@
+package lookup
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/make17better/goju/pkg/goju"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat selects how lookup results are written
+type OutputFormat string
+
+const (
+	Text OutputFormat = "text"
+	JSON OutputFormat = "json"
+	YAML OutputFormat = "yaml"
+	CSV  OutputFormat = "csv"
+	TSV  OutputFormat = "tsv"
+)
+
+// Formatter writes lookup results in one output format
+type Formatter interface {
+	Format(results []LookupResult) (string, error)
+}
+
+// NewFormatter returns the formatter for an output format. Mnemonics are
+// given in language; detail adds them and example words to text output,
+// while the other formats always include the mnemonic.
+func NewFormatter(format OutputFormat, language string, detail bool) (Formatter, error) {
+	switch format {
+	case Text:
+		return textFormatter{language: language, detail: detail}, nil
+	case JSON:
+		return jsonFormatter{language: language}, nil
+	case YAML:
+		return yamlFormatter{language: language}, nil
+	case CSV:
+		return delimitedFormatter{language: language, comma: ','}, nil
+	case TSV:
+		return delimitedFormatter{language: language, comma: '\t'}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want text, json, yaml, csv or tsv)", format)
+}
+
+// Record is the stable schema of one lookup result in the structured
+// formats. Every field is present, empty when it does not apply: Word,
+// Morae and Syllables are only set for words, the character fields only
+// for characters, and only the input fields for results that were not found.
+type Record struct {
+	Input      string   `json:"input" yaml:"input"`
+	Token      string   `json:"token" yaml:"token"`           // part of the input the record is for, such as タ of かタna or i of aiu
+	Offset     int      `json:"offset" yaml:"offset"`         // byte offset of Token in the normalized input
+	InputType  string   `json:"input_type" yaml:"input_type"` // type the input, or the part of it, was looked up as
+	Detected   bool     `json:"detected" yaml:"detected"`     // InputType was detected rather than given
+	Found      bool     `json:"found" yaml:"found"`
+	Hiragana   string   `json:"hiragana" yaml:"hiragana"`
+	Katakana   string   `json:"katakana" yaml:"katakana"`
+	Romaji     string   `json:"romaji" yaml:"romaji"`
+	IPA        string   `json:"ipa" yaml:"ipa"`
+	Category   string   `json:"category" yaml:"category"`
+	Mnemonic   string   `json:"mnemonic" yaml:"mnemonic"`
+	Candidates []string `json:"candidates" yaml:"candidates"` // hiragana of every match, most frequent first
+	Word       string   `json:"word" yaml:"word"`
+	Morae      []string `json:"morae" yaml:"morae"`
+	Syllables  []string `json:"syllables" yaml:"syllables"`
+}
+
+// recordColumns are the CSV and TSV header, in Record field order
+var recordColumns = []string{
+	"input", "token", "offset", "input_type", "detected", "found",
+	"hiragana", "katakana", "romaji", "ipa", "category", "mnemonic",
+	"candidates", "word", "morae", "syllables",
+}
+
+// NewRecord builds the structured form of a lookup result
+func NewRecord(result LookupResult, language string) Record {
+	record := Record{
+		Input:      result.Input,
+		Token:      result.Token,
+		Offset:     result.Offset,
+		InputType:  result.InputType,
+		Detected:   result.Detected,
+		Found:      result.Found,
+		Candidates: []string{},
+		Morae:      []string{},
+		Syllables:  []string{},
+	}
+	switch {
+	case !result.Found:
+	case result.Word != "":
+		record.Word = result.Word
+		// A partial reading such as "kyaっ" is left out
+		if romaji, err := goju.TransliterateIn(result.Word, result.System); err == nil {
+			record.Romaji = romaji
+		}
+		record.IPA = goju.IPA(result.Word)
+		for _, mora := range result.Morae {
+			record.Morae = append(record.Morae, mora.Text)
+		}
+		for _, syllable := range result.Syllables {
+			record.Syllables = append(record.Syllables, syllable.Text)
+		}
+	default:
+		char := result.Character
+		record.Hiragana = char.Hiragana
+		record.Katakana = char.Katakana
+		record.Romaji = char.RomajiIn(result.System)
+		record.IPA = char.IPA
+		record.Category = string(char.Category)
+		if mnemonic, ok := goju.GetMnemonic(char.ID); ok {
+			record.Mnemonic = mnemonic.Text(language)
+		}
+		for _, candidate := range result.Candidates {
+			record.Candidates = append(record.Candidates, candidate.Hiragana)
+		}
+	}
+	return record
+}
+
+// records builds the structured form of every result
+func records(results []LookupResult, language string) []Record {
+	out := make([]Record, len(results))
+	for i, result := range results {
+		out[i] = NewRecord(result, language)
+	}
+	return out
+}
+
+// textFormatter writes the human-readable format
+type textFormatter struct {
+	language string
+	detail   bool
+}
+
+func (f textFormatter) Format(results []LookupResult) (string, error) {
+	if f.detail {
+		return FormatBatchLookupDetail(results, f.language), nil
+	}
+	return FormatBatchLookup(results), nil
+}
+
+// jsonFormatter writes an indented JSON array of records
+type jsonFormatter struct {
+	language string
+}
+
+func (f jsonFormatter) Format(results []LookupResult) (string, error) {
+	data, err := json.MarshalIndent(records(results, f.language), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// yamlFormatter writes a YAML sequence of records
+type yamlFormatter struct {
+	language string
+}
+
+func (f yamlFormatter) Format(results []LookupResult) (string, error) {
+	data, err := yaml.Marshal(records(results, f.language))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// delimitedFormatter writes a header row then one row per record. Lists
+// are joined with spaces.
+type delimitedFormatter struct {
+	language string
+	comma    rune
+}
+
+func (f delimitedFormatter) Format(results []LookupResult) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = f.comma
+	if err := w.Write(recordColumns); err != nil {
+		return "", err
+	}
+	for _, record := range records(results, f.language) {
+		row := []string{
+			record.Input,
+			record.Token,
+			strconv.Itoa(record.Offset),
+			record.InputType,
+			strconv.FormatBool(record.Detected),
+			strconv.FormatBool(record.Found),
+			record.Hiragana,
+			record.Katakana,
+			record.Romaji,
+			record.IPA,
+			record.Category,
+			record.Mnemonic,
+			strings.Join(record.Candidates, " "),
+			record.Word,
+			strings.Join(record.Morae, " "),
+			strings.Join(record.Syllables, " "),
+		}
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
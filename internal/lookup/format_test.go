@@ -0,0 +1,109 @@
+package lookup
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestStructuredFormats(t *testing.T) {
+	results := BatchLookup("hiragana", []string{"あ", "ねこ", "きゃっ", "x"})
+	want := []Record{
+		{
+			Input: "あ", Token: "あ", InputType: "hiragana", Found: true, Hiragana: "あ", Katakana: "ア", Romaji: "a", IPA: "a",
+			Category: "seion", Mnemonic: "An apple with a cross on top: a for apple",
+			Candidates: []string{"あ"}, Morae: []string{}, Syllables: []string{},
+		},
+		{
+			Input: "ねこ", Token: "ねこ", InputType: "hiragana", Found: true, Romaji: "neko", IPA: "neko", Word: "ねこ",
+			Candidates: []string{}, Morae: []string{"ね", "こ"}, Syllables: []string{"ね", "こ"},
+		},
+		{
+			Input: "きゃっ", Token: "きゃっ", InputType: "hiragana", Found: true, IPA: "kʲaʔ", Word: "きゃっ",
+			Candidates: []string{}, Morae: []string{"きゃ", "っ"}, Syllables: []string{"きゃっ"},
+		},
+		{Input: "x", Token: "x", InputType: "hiragana", Candidates: []string{}, Morae: []string{}, Syllables: []string{}},
+	}
+
+	unmarshal := map[OutputFormat]func([]byte, any) error{
+		JSON: json.Unmarshal,
+		YAML: yaml.Unmarshal,
+	}
+	for format, decode := range unmarshal {
+		t.Run(string(format), func(t *testing.T) {
+			formatter, err := NewFormatter(format, "en", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			output, err := formatter.Format(results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []Record
+			if err := decode([]byte(output), &got); err != nil {
+				t.Fatalf("output does not parse: %v\n%s", err, output)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Format() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDelimitedFormats(t *testing.T) {
+	results := BatchLookup("romaji", []string{"kitte", "q"})
+	for _, tt := range []struct {
+		format OutputFormat
+		comma  rune
+	}{{CSV, ','}, {TSV, '\t'}} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			formatter, err := NewFormatter(tt.format, "en", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			output, err := formatter.Format(results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := csv.NewReader(strings.NewReader(output))
+			r.Comma = tt.comma
+			rows, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("output does not parse: %v\n%s", err, output)
+			}
+			if len(rows) != 5 {
+				t.Fatalf("got %d rows, want a header and 4 results:\n%s", len(rows), output)
+			}
+			if !reflect.DeepEqual(rows[0], recordColumns) {
+				t.Errorf("header = %v, want %v", rows[0], recordColumns)
+			}
+			if got := rows[1][:7]; !reflect.DeepEqual(got, []string{"kitte", "ki", "0", "romaji", "false", "true", "き"}) {
+				t.Errorf("first row starts %v, want kitte ki 0 romaji false true き", got)
+			}
+			if got := rows[2][:3]; !reflect.DeepEqual(got, []string{"kitte", "t", "2"}) {
+				t.Errorf("second row starts %v, want kitte t 2", got)
+			}
+			if got := rows[4][:7]; !reflect.DeepEqual(got, []string{"q", "q", "0", "romaji", "false", "false", ""}) {
+				t.Errorf("last row starts %v, want q q 0 romaji false false and no kana", got)
+			}
+		})
+	}
+}
+
+func TestNewFormatter(t *testing.T) {
+	formatter, err := NewFormatter(Text, "en", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := BatchLookup("hiragana", []string{"あ"})
+	if got, _ := formatter.Format(results); got != FormatBatchLookup(results) {
+		t.Errorf("text Format() = %q, want FormatBatchLookup output", got)
+	}
+	if _, err := NewFormatter("xml", "en", false); err == nil {
+		t.Error("NewFormatter(xml) succeeded, want an error")
+	}
+}
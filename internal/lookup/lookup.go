@@ -2,6 +2,7 @@ package lookup
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/make17better/goju/pkg/goju"
@@ -9,62 +10,490 @@ import (
 
 // LookupResult represents the result of a character lookup
 type LookupResult struct {
-	Character goju.Character
-	Found     bool
+	Input      string // value as given, before normalization
+	InputType  string // type the value was looked up as, such as Hiragana
+	Detected   bool   // InputType was detected rather than given
+	Token      string // part of Input this result is for: all of it, or a run or romaji unit when it was split
+	Offset     int    // byte offset of Token in Input, once normalized and trimmed of spaces
+	Character  goju.Character
+	Candidates []goju.Character // every match, most frequent first
+	Found      bool
+	System     goju.RomanizationSystem
+	Word       string          // kana looked up as a word, set instead of Character
+	Morae      []goju.Mora     // morae of Word
+	Syllables  []goju.Syllable // syllables of Word
 }
 
 // Lookup performs a character lookup based on the input type and value
 func Lookup(inputType, value string) LookupResult {
-	var result LookupResult
-	var found bool
-
-	switch strings.ToLower(inputType) {
-	case "hiragana":
-		result.Character, found = goju.GetCharacterByHiragana(value)
-	case "katakana":
-		result.Character, found = goju.GetCharacterByKatakana(value)
-	case "romaji":
-		result.Character, found = goju.GetCharacterByRomaji(value)
+	return LookupIn(inputType, value, goju.Hepburn)
+}
+
+// LookupIn performs a character lookup where romaji input and output use
+// the given romanization system. Romaji that is not valid in that system is
+// matched against the other systems. The value is normalized first, so
+// half-width, full-width and decomposed input all match. Kana that spell
+// more than one character are looked up as a word, see LookupWord.
+//
+// With the Detect input type, the type is worked out from the value. A
+// value that mixes scripts is not found here; BatchLookupIn splits it.
+func LookupIn(inputType, value string, system goju.RomanizationSystem) LookupResult {
+	inputType = strings.ToLower(inputType)
+	result := LookupResult{Input: value, InputType: inputType, System: system}
+	value = goju.Normalize(strings.TrimSpace(value))
+	result.Token = value
+
+	switch inputType {
+	case Detect:
+		tokens := Tokenize(value)
+		if len(tokens) != 1 {
+			result.InputType, result.Detected = Unknown, true
+			return result
+		}
+		detected := LookupIn(tokens[0].Type, result.Input, system)
+		detected.Detected, detected.Token, detected.Offset = true, tokens[0].Text, tokens[0].Start
+		return detected
+	case Hiragana:
+		if char, ok := goju.GetCharacterByHiragana(value); ok {
+			result.Candidates = []goju.Character{char}
+		} else {
+			return lookupWord(result, value)
+		}
+	case Katakana:
+		if char, ok := goju.GetCharacterByKatakana(value); ok {
+			result.Candidates = []goju.Character{char}
+		} else {
+			return lookupWord(result, value)
+		}
+	case Romaji:
+		result.Candidates = goju.FindByRomajiIn(value, system)
+		if len(result.Candidates) == 0 {
+			result.Candidates = goju.FindByRomaji(value)
+		}
 	default:
-		return LookupResult{Found: false}
+		return result
 	}
 
-	result.Found = found
+	if len(result.Candidates) > 0 {
+		result.Character = result.Candidates[0]
+		result.Found = true
+	}
 	return result
 }
 
-// FormatLookupResult formats the lookup result for display
+// LookupWord splits a kana word into morae and syllables. The word is
+// found when it has more than one mora and every mora is known.
+func LookupWord(value string, system goju.RomanizationSystem) LookupResult {
+	return lookupWord(LookupResult{Input: value, System: system}, value)
+}
+
+// lookupWord fills in a result for a kana word
+func lookupWord(result LookupResult, value string) LookupResult {
+	morae := goju.Morae(value)
+	if len(morae) < 2 || !coversInput(value, morae) {
+		return result
+	}
+	result.Word = value
+	result.Morae = morae
+	result.Syllables = goju.Syllables(value)
+	result.Found = true
+	return result
+}
+
+// coversInput reports whether the morae account for every byte of value
+func coversInput(value string, morae []goju.Mora) bool {
+	pos := 0
+	for _, mora := range morae {
+		if mora.Start != pos || mora.Char.ID == "" {
+			return false
+		}
+		pos = mora.End
+	}
+	return pos == len(value)
+}
+
+// FormatLookupResult formats the lookup result for display. For detected
+// input the first line names the type, such as "Detected katakana: タ".
 func FormatLookupResult(result LookupResult) string {
+	detected := ""
+	if result.Detected {
+		token := result.Token
+		if token == "" {
+			token = result.Input
+		}
+		detected = fmt.Sprintf("Detected %s: %s\n", result.InputType, token)
+	}
 	if !result.Found {
-		return "Character not found"
+		return detected + "Character not found"
+	}
+	if result.Word != "" {
+		return detected + formatWord(result)
 	}
 
-	return fmt.Sprintf(
-		"Hiragana: %s\nKatakana: %s\nRomaji: %s\nCategory: %s",
+	formatted := detected + fmt.Sprintf(
+		"Hiragana: %s\nKatakana: %s",
 		result.Character.Hiragana,
 		result.Character.Katakana,
-		result.Character.Romaji,
-		result.Character.Category,
 	)
+	// Marks such as ゝ have no romaji of their own
+	if romaji := result.Character.RomajiIn(result.System); romaji != "" {
+		formatted += fmt.Sprintf("\nRomaji: %s", romaji)
+	}
+	if result.Character.IPA != "" {
+		formatted += fmt.Sprintf("\nIPA: [%s]", result.Character.IPA)
+	}
+	formatted += fmt.Sprintf("\nCategory: %s", result.Character.Category)
+
+	if origin := FormatOrigin(result.Character); origin != "" {
+		formatted += fmt.Sprintf("\nOrigin: %s", origin)
+	}
+	if result.Character.Description != "" {
+		formatted += fmt.Sprintf("\nDescription: %s", result.Character.Description)
+	}
+	if len(result.Character.Tags) > 0 {
+		formatted += fmt.Sprintf("\nTags: %s", strings.Join(result.Character.Tags, ", "))
+	}
+	if result.Character.Notes != "" {
+		formatted += fmt.Sprintf("\nNotes: %s", result.Character.Notes)
+	}
+	script := goju.ScriptHiragana
+	if result.InputType == Katakana {
+		script = goju.ScriptKatakana
+	}
+	if similar := FormatSimilar(result.Character, script, result.System); similar != "" {
+		formatted += fmt.Sprintf("\nCommonly confused with: %s", similar)
+	}
+
+	if len(result.Candidates) > 1 {
+		others := make([]string, 0, len(result.Candidates)-1)
+		for _, char := range result.Candidates[1:] {
+			others = append(others, fmt.Sprintf("%s (%s)", char.Hiragana, char.Katakana))
+		}
+		formatted += fmt.Sprintf("\nAlso matches: %s", strings.Join(others, ", "))
+	}
+
+	return formatted
+}
+
+// detailWords is the number of example words shown in the detail view
+const detailWords = 3
+
+// formatWord formats a word result with its reading, pronunciation, morae
+// and syllables, each split with a middle dot. A word that cannot be written
+// in romaji, such as one ending in っ, says why instead of a partial reading.
+func formatWord(result LookupResult) string {
+	romaji, err := goju.TransliterateIn(result.Word, result.System)
+	if err != nil {
+		romaji = fmt.Sprintf("none (%v)", err)
+	}
+	morae := make([]string, len(result.Morae))
+	for i, mora := range result.Morae {
+		morae[i] = mora.Text
+	}
+	syllables := make([]string, len(result.Syllables))
+	for i, syllable := range result.Syllables {
+		syllables[i] = syllable.Text
+	}
+	return fmt.Sprintf(
+		"Word: %s\nRomaji: %s\nIPA: [%s]\nMorae: %d (%s)\nSyllables: %d (%s)",
+		result.Word,
+		romaji,
+		goju.IPA(result.Word),
+		len(morae), strings.Join(morae, "・"),
+		len(syllables), strings.Join(syllables, "・"),
+	)
+}
+
+// FormatLookupDetail formats the lookup result with everything goju knows
+// about the character: its place in the gojūon table, its spelling in every
+// romanization system, its voiced forms, code points, stroke counts and
+// frequency, then the mnemonic and example words in the given language,
+// words starting with the character first as GetWordsFor ranks them.
+// Words get their code points.
+func FormatLookupDetail(result LookupResult, language string) string {
+	formatted := FormatLookupResult(result)
+	if !result.Found {
+		return formatted
+	}
+	if result.Word != "" {
+		return formatted + fmt.Sprintf("\nUnicode: %s", FormatCodepoints(result.Word))
+	}
+
+	char := result.Character
+	if char.Row != "" {
+		position := fmt.Sprintf("%s row", char.Row)
+		if char.Column != "" {
+			position += fmt.Sprintf(", %s column", char.Column)
+		}
+		formatted += fmt.Sprintf("\nPosition: %s", position)
+	}
+	if romanizations := formatRomanizations(char); romanizations != "" {
+		formatted += fmt.Sprintf("\nRomanization: %s", romanizations)
+	}
+	if voicing := formatVoicing(char); voicing != "" {
+		formatted += fmt.Sprintf("\nVoicing: %s", voicing)
+	}
+	formatted += fmt.Sprintf("\nUnicode: %s, %s", FormatCodepoints(char.Hiragana), FormatCodepoints(char.Katakana))
+	if strokes := formatStrokes(char); strokes != "" {
+		formatted += fmt.Sprintf("\nStrokes: %s", strokes)
+	}
+	if frequency := char.Frequency(); frequency > 0 {
+		formatted += fmt.Sprintf("\nFrequency: %d per 10,000 kana", frequency)
+	}
+	if mnemonic, ok := goju.GetMnemonic(result.Character.ID); ok {
+		formatted += fmt.Sprintf("\nMnemonic: %s", mnemonic.Text(language))
+	}
+	// The words keep GetWordsFor's ranking rather than dictionary order, so
+	// a word starting with the character is shown before one ending in it
+	if words := goju.GetWordsFor(result.Character); len(words) > 0 {
+		if len(words) > detailWords {
+			words = words[:detailWords]
+		}
+		examples := make([]string, len(words))
+		for i, word := range words {
+			examples[i] = word.Format(result.System, language)
+		}
+		formatted += fmt.Sprintf("\nWords: %s", strings.Join(examples, "; "))
+	}
+	return formatted
+}
+
+// formatRomanizations lists a character's spelling in every system, such as
+// "Hepburn shi, Kunrei si, Nihon si", and is empty for a character with no
+// romaji
+func formatRomanizations(char goju.Character) string {
+	if char.RomajiIn(goju.Hepburn) == "" {
+		return ""
+	}
+	spellings := make([]string, 0, len(goju.RomanizationSystems))
+	for _, system := range goju.RomanizationSystems {
+		name := strings.ToUpper(string(system[:1])) + string(system[1:])
+		spellings = append(spellings, fmt.Sprintf("%s %s", name, char.RomajiIn(system)))
+	}
+	return strings.Join(spellings, ", ")
+}
+
+// formatVoicing shows the plain, dakuten and handakuten forms of the
+// character's row, such as "は → ば → ぱ", or nothing for kana that do
+// not change
+func formatVoicing(char goju.Character) string {
+	base := char
+	if plain, ok := goju.BaseOf(char); ok {
+		base = plain
+	}
+	forms := []string{base.Hiragana}
+	if voiced, ok := goju.VoicedOf(base); ok {
+		forms = append(forms, voiced.Hiragana)
+	}
+	if semiVoiced, ok := goju.SemiVoicedOf(base); ok {
+		forms = append(forms, semiVoiced.Hiragana)
+	}
+	if len(forms) == 1 {
+		return ""
+	}
+	return strings.Join(forms, " → ")
+}
+
+// FormatCodepoints writes kana with the Unicode code point of each rune,
+// such as "きゃ U+304D U+3083"
+func FormatCodepoints(kana string) string {
+	parts := []string{kana}
+	for _, r := range kana {
+		parts = append(parts, fmt.Sprintf("U+%04X", r))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatStrokes gives the stroke count of each script the character has
+// stroke data for, such as "3 (あ), 2 (ア)"
+func formatStrokes(char goju.Character) string {
+	var counts []string
+	for _, script := range []goju.Script{goju.ScriptHiragana, goju.ScriptKatakana} {
+		if strokes, ok := char.Strokes(script); ok {
+			kana := char.Hiragana
+			if script == goju.ScriptKatakana {
+				kana = char.Katakana
+			}
+			counts = append(counts, fmt.Sprintf("%d (%s)", len(strokes), kana))
+		}
+	}
+	return strings.Join(counts, ", ")
+}
+
+// FormatOrigin describes the man'yōgana a character's kana developed from,
+// such as "あ from 安, ア from 阿"
+func FormatOrigin(char goju.Character) string {
+	var parts []string
+	if char.HiraganaOrigin != "" {
+		parts = append(parts, fmt.Sprintf("%s from %s", char.Hiragana, char.HiraganaOrigin))
+	}
+	if char.KatakanaOrigin != "" {
+		parts = append(parts, fmt.Sprintf("%s from %s", char.Katakana, char.KatakanaOrigin))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatSimilar lists the characters that look like char, most confusable
+// first, such as "ツ (tsu), ン (n)" for シ. A look-alike in both scripts,
+// such as ぱ for ば, is listed once, written in script.
+func FormatSimilar(char goju.Character, script goju.Script, system goju.RomanizationSystem) string {
+	inScript := make(map[string]bool)
+	for _, confusable := range goju.SimilarIn(char, script) {
+		inScript[confusable.Character.ID] = true
+	}
+	var parts []string
+	for _, confusable := range goju.SimilarTo(char) {
+		if confusable.Script != script && inScript[confusable.Character.ID] {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", confusable.Kana(), confusable.Character.RomajiIn(system)))
+	}
+	return strings.Join(parts, ", ")
 }
 
 // BatchLookup performs multiple character lookups
 func BatchLookup(inputType string, values []string) []LookupResult {
-	results := make([]LookupResult, len(values))
-	for i, value := range values {
-		results[i] = Lookup(inputType, value)
+	return BatchLookupIn(inputType, values, goju.Hepburn)
+}
+
+// BatchLookupIn performs multiple character lookups in the given romanization
+// system. A romaji value that is not one character, such as "aiu", is split
+// into units and gives one result per unit, using the parse with the fewest
+// units; see FormatParses for the others. With the Detect input type each
+// value is split into runs of one script, see Tokenize, and each run is
+// looked up as the type detected for it, so かタna gives か, タ and な.
+func BatchLookupIn(inputType string, values []string, system goju.RomanizationSystem) []LookupResult {
+	results := make([]LookupResult, 0, len(values))
+	for _, value := range values {
+		if !strings.EqualFold(inputType, Detect) {
+			results = append(results, lookupValue(inputType, value, system)...)
+			continue
+		}
+		tokens := Tokenize(strings.TrimSpace(value))
+		if len(tokens) == 0 {
+			results = append(results, LookupIn(Detect, value, system))
+		}
+		for _, token := range tokens {
+			for _, result := range lookupValue(token.Type, token.Text, system) {
+				result.Input, result.InputType, result.Detected = value, token.Type, true
+				result.Offset += token.Start
+				results = append(results, result)
+			}
+		}
 	}
 	return results
 }
 
+// lookupValue looks up one value, splitting romaji words into units
+func lookupValue(inputType, value string, system goju.RomanizationSystem) []LookupResult {
+	result := LookupIn(inputType, value, system)
+	if result.Found || !strings.EqualFold(inputType, Romaji) {
+		return []LookupResult{result}
+	}
+	parses := goju.Segment(value)
+	if len(parses) == 0 {
+		return []LookupResult{result}
+	}
+	results := make([]LookupResult, len(parses[0]))
+	for i, unit := range parses[0] {
+		results[i] = lookupUnit(unit, system)
+		results[i].Input, results[i].Token, results[i].Offset = value, unit.Text, unit.Start
+	}
+	return results
+}
+
+// lookupUnit looks up one unit of segmented romaji. Units with no spelling
+// of their own, such as the k of kk, take the unit's characters.
+func lookupUnit(unit goju.RomajiUnit, system goju.RomanizationSystem) LookupResult {
+	result := LookupIn("romaji", unit.Text, system)
+	if !result.Found {
+		result.Candidates = unit.Chars
+		result.Character = unit.Chars[0]
+		result.Found = true
+	}
+	return result
+}
+
+// FormatParses lists the ways romaji can be split when there is more than
+// one, such as "shinya: shi・nya (しにゃ) or shi・n・ya (しんや)", and is empty
+// otherwise
+func FormatParses(value string) string {
+	parses := goju.Segment(value)
+	if len(parses) < 2 {
+		return ""
+	}
+	readings := make([]string, len(parses))
+	for i, parse := range parses {
+		texts := make([]string, len(parse))
+		for j, unit := range parse {
+			texts[j] = unit.Text
+		}
+		readings[i] = fmt.Sprintf("%s (%s)", strings.Join(texts, "・"), goju.SegmentKana(parse, goju.ScriptHiragana))
+	}
+	return fmt.Sprintf("%s: %s", value, strings.Join(readings, " or "))
+}
+
 // FormatBatchLookup formats multiple lookup results for display
 func FormatBatchLookup(results []LookupResult) string {
+	return formatBatch(results, FormatLookupResult)
+}
+
+// FormatBatchLookupDetail formats multiple lookup results with their mnemonics
+func FormatBatchLookupDetail(results []LookupResult, language string) string {
+	return formatBatch(results, func(result LookupResult) string {
+		return FormatLookupDetail(result, language)
+	})
+}
+
+// SortResults puts the values of a batch lookup in the collator's order.
+// The results of one value, such as the units of romaji aiu, stay together
+// in the order they were split; values that were not found sort by their
+// text after all kana.
+func SortResults(results []LookupResult, c goju.Collator) {
+	type group struct {
+		results []LookupResult
+		kana    string
+	}
+	var groups []group
+	for i, result := range results {
+		if i == 0 || result.Input != results[i-1].Input {
+			groups = append(groups, group{})
+		}
+		last := &groups[len(groups)-1]
+		last.results = append(last.results, result)
+		last.kana += resultKana(result)
+	}
+	sort.SliceStable(groups, func(a, b int) bool {
+		return c.Less(groups[a].kana, groups[b].kana)
+	})
+	i := 0
+	for _, group := range groups {
+		i += copy(results[i:], group.results)
+	}
+}
+
+// resultKana returns the kana a result is for, in the script it was looked
+// up in, or the text looked up when it was not found
+func resultKana(result LookupResult) string {
+	switch {
+	case !result.Found:
+		return result.Token
+	case result.Word != "":
+		return result.Word
+	case result.InputType == Katakana:
+		return result.Character.Katakana
+	}
+	return result.Character.Hiragana
+}
+
+// formatBatch joins formatted results with a blank line between them
+func formatBatch(results []LookupResult, format func(LookupResult) string) string {
 	var sb strings.Builder
 	for i, result := range results {
 		if i > 0 {
 			sb.WriteString("\n\n")
 		}
-		sb.WriteString(FormatLookupResult(result))
+		sb.WriteString(format(result))
 	}
 	return sb.String()
 }
@@ -1,6 +1,8 @@
 package lookup
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/make17better/goju/pkg/goju"
@@ -16,8 +18,17 @@ func TestLookup(t *testing.T) {
 		{"Valid hiragana", "hiragana", "あ", true},
 		{"Valid katakana", "katakana", "ア", true},
 		{"Valid romaji", "romaji", "a", true},
+		{"Loanword katakana", "katakana", "ファ", true},
+		{"Sokuon", "hiragana", "っ", true},
+		{"Long vowel mark", "katakana", "ー", true},
+		{"Iteration mark", "hiragana", "ゝ", true},
+		{"Half-width katakana", "katakana", "ｶﾞ", true},
+		{"Full-width romaji", "romaji", "ＳＨＩ", true},
+		{"Decomposed hiragana", "hiragana", "は\u309A", true},
 		{"Invalid type", "invalid", "あ", false},
-		{"Invalid value", "hiragana", "ああ", false},
+		{"Invalid value", "hiragana", "あx", false},
+		{"Kana word", "hiragana", "ああ", true},
+		{"Katakana word", "katakana", "ラーメン", true},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +41,34 @@ func TestLookup(t *testing.T) {
 	}
 }
 
+func TestLookupCandidates(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputType      string
+		value          string
+		wantCharacter  string
+		wantCandidates int
+	}{
+		{"Ambiguous ji", "romaji", "ji", "じ", 2},
+		{"Ambiguous zu", "romaji", "zu", "ず", 2},
+		{"Other system", "romaji", "si", "し", 1},
+		{"Unique", "romaji", "ka", "か", 1},
+		{"Hiragana", "hiragana", "ぢ", "ぢ", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Lookup(tt.inputType, tt.value)
+			if result.Character.Hiragana != tt.wantCharacter {
+				t.Errorf("Lookup() character = %v, want %v", result.Character.Hiragana, tt.wantCharacter)
+			}
+			if len(result.Candidates) != tt.wantCandidates {
+				t.Errorf("Lookup() candidates = %v, want %v", len(result.Candidates), tt.wantCandidates)
+			}
+		})
+	}
+}
+
 func TestBatchLookup(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -40,6 +79,9 @@ func TestBatchLookup(t *testing.T) {
 		{"Valid hiragana batch", "hiragana", []string{"あ", "い", "う"}, 3},
 		{"Valid katakana batch", "katakana", []string{"ア", "イ", "ウ"}, 3},
 		{"Valid romaji batch", "romaji", []string{"a", "i", "u"}, 3},
+		{"Romaji word", "romaji", []string{"aiu"}, 3},
+		{"Romaji words", "romaji", []string{"kitte", "shinya"}, 5},
+		{"Unsplittable romaji", "romaji", []string{"kq"}, 1},
 		{"Mixed valid/invalid", "hiragana", []string{"あ", "ああ", "い"}, 3},
 		{"Empty batch", "hiragana", []string{}, 0},
 	}
@@ -54,6 +96,53 @@ func TestBatchLookup(t *testing.T) {
 	}
 }
 
+func TestBatchLookupSegments(t *testing.T) {
+	results := BatchLookup("romaji", []string{"kitte"})
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Character.Hiragana)
+	}
+	if strings.Join(got, "") != "きって" {
+		t.Errorf("BatchLookup(kitte) = %v, want き っ て", got)
+	}
+
+	if got, want := FormatParses("shinya"), "shinya: shi・nya (しにゃ) or shi・n・ya (しんや)"; got != want {
+		t.Errorf("FormatParses(shinya) = %q, want %q", got, want)
+	}
+	if got := FormatParses("kanai"); got != "" {
+		t.Errorf("FormatParses(kanai) = %q, want no alternatives", got)
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputType string
+		values    []string
+		want      []string // token of each result
+	}{
+		{"Hiragana", "hiragana", []string{"さ", "か", "あ"}, []string{"あ", "か", "さ"}},
+		{"Voicing after plain", "hiragana", []string{"が", "か", "き"}, []string{"か", "が", "き"}},
+		{"Words", "katakana", []string{"ラーメン", "カード", "イ"}, []string{"イ", "カード", "ラーメン"}},
+		{"Romaji units stay together", "romaji", []string{"kitte", "aiu"}, []string{"a", "i", "u", "ki", "t", "te"}},
+		{"Not found last", "hiragana", []string{"x", "か"}, []string{"か", "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := BatchLookup(tt.inputType, tt.values)
+			SortResults(results, goju.Collator{})
+			var got []string
+			for _, result := range results {
+				got = append(got, result.Token)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortResults(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatLookupResult(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -73,6 +162,26 @@ func TestFormatLookupResult(t *testing.T) {
 			},
 			"Hiragana: あ\nKatakana: ア\nRomaji: a\nCategory: seion",
 		},
+		{
+			"Multiple candidates",
+			Lookup("romaji", "ji"),
+			"Hiragana: じ\nKatakana: ジ\nRomaji: ji\nIPA: [dʑi]\nCategory: dakuon\nOrigin: じ from 之, ジ from 之\nAlso matches: ぢ (ヂ)",
+		},
+		{
+			"Modifier with description",
+			Lookup("hiragana", "っ"),
+			"Hiragana: っ\nKatakana: ッ\nRomaji: xtsu\nCategory: modifier\nOrigin: っ from 川, ッ from 川\nDescription: Sokuon; doubles the consonant of the following kana, as in がっこう (gakkou)",
+		},
+		{
+			"Origin differs by script",
+			Lookup("hiragana", "あ"),
+			"Hiragana: あ\nKatakana: ア\nRomaji: a\nIPA: [a]\nCategory: seion\nOrigin: あ from 安, ア from 阿\nCommonly confused with: お (o), マ (ma), め (me)",
+		},
+		{
+			"No romaji",
+			Lookup("hiragana", "ゝ"),
+			"Hiragana: ゝ\nKatakana: ヽ\nCategory: modifier\nDescription: Iteration mark; repeats the preceding kana, as in こゝろ (kokoro)",
+		},
 		{
 			"Not found",
 			LookupResult{Found: false},
@@ -90,6 +199,95 @@ func TestFormatLookupResult(t *testing.T) {
 	}
 }
 
+func TestLookupWord(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantMorae     int
+		wantSyllables int
+		want          string
+	}{
+		{"Yoon", "きゃく", 2, 2, "Word: きゃく\nRomaji: kyaku\nIPA: [kʲakɯ]\nMorae: 2 (きゃ・く)\nSyllables: 2 (きゃ・く)"},
+		{"Sokuon and long vowel", "がっこう", 4, 2, "Word: がっこう\nRomaji: gakkou\nIPA: [ɡakkoː]\nMorae: 4 (が・っ・こ・う)\nSyllables: 2 (がっ・こう)"},
+		{"Choonpu and n", "ラーメン", 4, 2, "Word: ラーメン\nRomaji: raamen\nIPA: [ɾaːmeɴ]\nMorae: 4 (ラ・ー・メ・ン)\nSyllables: 2 (ラー・メン)"},
+		{"Final sokuon", "きゃっ", 2, 1, "Word: きゃっ\nRomaji: none (cannot transliterate \"っ\" at byte 6)\nIPA: [kʲaʔ]\nMorae: 2 (きゃ・っ)\nSyllables: 1 (きゃっ)"},
+		{"Single character", "か", 0, 0, "Character not found"},
+		{"Unknown text", "かx", 0, 0, "Character not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LookupWord(tt.value, goju.Hepburn)
+			if len(result.Morae) != tt.wantMorae || len(result.Syllables) != tt.wantSyllables {
+				t.Errorf("LookupWord(%s) = %d morae, %d syllables, want %d, %d", tt.value, len(result.Morae), len(result.Syllables), tt.wantMorae, tt.wantSyllables)
+			}
+			if got := FormatLookupResult(result); got != tt.want {
+				t.Errorf("FormatLookupResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLookupDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   LookupResult
+		language string
+		want     string
+	}{
+		{
+			"English mnemonic",
+			Lookup("hiragana", "あ"),
+			"en",
+			"Hiragana: あ\nKatakana: ア\nRomaji: a\nIPA: [a]\nCategory: seion\nOrigin: あ from 安, ア from 阿\nCommonly confused with: お (o), マ (ma), め (me)\nPosition: a row, a column\nRomanization: Hepburn a, Kunrei a, Nihon a\nUnicode: あ U+3042, ア U+30A2\nStrokes: 3 (あ), 2 (ア)\nFrequency: 170 per 10,000 kana\nMnemonic: An apple with a cross on top: a for apple\nWords: あさ 朝 (asa) morning; あめ 雨 (ame) rain",
+		},
+		{
+			"Traditional Chinese mnemonic",
+			Lookup("hiragana", "ね"),
+			"zh-tw",
+			"Hiragana: ね\nKatakana: ネ\nRomaji: ne\nIPA: [ne]\nCategory: seion\nOrigin: ね from 祢, ネ from 祢\nCommonly confused with: わ (wa), れ (re), ぬ (nu), ホ (ho)\nPosition: na row, e column\nRomanization: Hepburn ne, Kunrei ne, Nihon ne\nUnicode: ね U+306D, ネ U+30CD\nStrokes: 2 (ね), 4 (ネ)\nFrequency: 40 per 10,000 kana\nMnemonic: 「禰」字草書，尾巴捲起像貓(neko)\nWords: ねこ 猫 (neko) 貓; おかね お金 (okane) 錢; ふね 船 (fune) 船",
+		},
+		{
+			"Loanword without kanji",
+			Lookup("katakana", "ペ"),
+			"en",
+			"Hiragana: ぺ\nKatakana: ペ\nRomaji: pe\nIPA: [pe]\nCategory: handaku\nOrigin: ぺ from 部, ペ from 部\nCommonly confused with: ベ (be)\nPosition: pa row, e column\nRomanization: Hepburn pe, Kunrei pe, Nihon pe\nVoicing: へ → べ → ぺ\nUnicode: ぺ U+307A, ペ U+30DA\nStrokes: 2 (ぺ), 2 (ペ)\nFrequency: 2 per 10,000 kana\nWords: ペン (pen) pen",
+		},
+		{
+			"No romaji",
+			Lookup("hiragana", "ゝ"),
+			"en",
+			"Hiragana: ゝ\nKatakana: ヽ\nCategory: modifier\nDescription: Iteration mark; repeats the preceding kana, as in こゝろ (kokoro)\nVoicing: ゝ → ゞ\nUnicode: ゝ U+309D, ヽ U+30FD\nStrokes: 1 (ゝ), 1 (ヽ)",
+		},
+		{
+			"No mnemonic",
+			Lookup("hiragana", "きゃ"),
+			"en",
+			"Hiragana: きゃ\nKatakana: キャ\nRomaji: kya\nIPA: [kʲa]\nCategory: yoon\nRomanization: Hepburn kya, Kunrei kya, Nihon kya\nVoicing: きゃ → ぎゃ\nUnicode: きゃ U+304D U+3083, キャ U+30AD U+30E3\nFrequency: 3 per 10,000 kana",
+		},
+		{
+			"Word",
+			Lookup("hiragana", "ねこ"),
+			"en",
+			"Word: ねこ\nRomaji: neko\nIPA: [neko]\nMorae: 2 (ね・こ)\nSyllables: 2 (ね・こ)\nUnicode: ねこ U+306D U+3053",
+		},
+		{
+			"Not found",
+			LookupResult{Found: false},
+			"en",
+			"Character not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatLookupDetail(tt.result, tt.language); got != tt.want {
+				t.Errorf("FormatLookupDetail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // CharacterArrayLookup looks up multiple characters at once
 func CharacterArrayLookup(input []string) ([]*goju.Character, error) {
 	results := make([]*goju.Character, len(input))
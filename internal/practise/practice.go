@@ -1,7 +1,11 @@
 package practise
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/make17better/goju/pkg/goju"
@@ -21,20 +25,39 @@ type PracticeResult struct {
 // Mistake represents a mistake made during practice
 type Mistake struct {
 	Character goju.Character
+	Word      goju.Word // set instead of Character in word-reading sessions
 	Input     string
 	Attempts  int
 	Correct   bool
 	TimeSpent time.Duration
 }
 
+// key identifies what was missed, the word if there is one
+func (m Mistake) key() string {
+	if m.Word.Kana != "" {
+		return m.Word.Kana
+	}
+	return m.Character.Romaji
+}
+
 // PracticeSession represents an ongoing practice session
 type PracticeSession struct {
 	Count      int
 	Categories []string
+	Rows       []goju.Row // when set, only characters in these rows are asked
+	Deck       string     // when set, characters come from this deck instead of Categories
+	System     goju.RomanizationSystem
+	Language   string // language of hints, such as "en" or "zh"
+	Words      bool   // when set, example words are read instead of single characters
+	Choices    int    // when above 1, each question offers this many romaji options
+	Drill      string // when set, only this kana and the kana that look like it are asked
 	Results    []PracticeResult
 	StartTime  time.Time
 	Current    struct {
 		Character goju.Character
+		Word      goju.Word
+		Options   []string    // romaji to choose from in multiple-choice sessions
+		Script    goju.Script // script the character is shown in
 		Input     string
 		Attempts  int
 		StartTime time.Time
@@ -46,19 +69,32 @@ func NewPracticeSession(count int, categories []string) *PracticeSession {
 	return &PracticeSession{
 		Count:      count,
 		Categories: categories,
+		System:     goju.Hepburn,
+		Language:   "en",
 		StartTime:  time.Now(),
 	}
 }
 
-// GetNextCharacter returns a random character from the specified categories
-func (p *PracticeSession) GetNextCharacter() goju.Character {
-	var availableChars []goju.Character
-	for _, category := range p.Categories {
-		if chars, ok := goju.Characters[goju.Category(category)]; ok {
-			availableChars = append(availableChars, chars...)
-		}
+// NextQuestion starts a new question, a character or in word-reading
+// sessions an example word
+func (p *PracticeSession) NextQuestion() {
+	p.Current.Input = ""
+	p.Current.Attempts = 0
+	p.Current.Character = goju.Character{}
+	p.Current.Word = goju.Word{}
+	p.Current.Script = p.drillScript()
+	if p.Words {
+		p.Current.Word = p.GetNextWord()
+	} else {
+		p.Current.Character = p.GetNextCharacter()
 	}
+	p.Current.Options = p.options()
+	p.Current.StartTime = time.Now()
+}
 
+// GetNextCharacter returns a random character from the specified categories
+func (p *PracticeSession) GetNextCharacter() goju.Character {
+	availableChars := p.availableCharacters()
 	if len(availableChars) == 0 {
 		return goju.Character{}
 	}
@@ -67,9 +103,248 @@ func (p *PracticeSession) GetNextCharacter() goju.Character {
 	return availableChars[rand.Intn(len(availableChars))]
 }
 
-// CheckAnswer checks if the provided answer is correct
+// GetNextWord returns a random example word spelled only with the session's
+// characters and marks such as っ and ー, or an empty word when there is
+// none; see Validate
+func (p *PracticeSession) GetNextWord() goju.Word {
+	rand.Seed(time.Now().UnixNano())
+	word, _ := goju.RandomWord(p.availableWords())
+	return word
+}
+
+// availableWords returns the example words the session can ask about
+func (p *PracticeSession) availableWords() []goju.Word {
+	known := append(p.availableCharacters(), goju.GetCharactersByCategory(goju.Modifier)...)
+	return goju.GetWordsUsing(known)
+}
+
+// Validate reports an error when the session has nothing to ask: the
+// drilled kana is unknown or a modifier, no characters pass its filters or,
+// when reading words, no example word is spelled only with those characters
+func (p *PracticeSession) Validate() error {
+	if p.Drill != "" {
+		char, ok := goju.DefaultRegistry().ByKana(p.Drill)
+		if !ok {
+			return fmt.Errorf("unknown kana %q", p.Drill)
+		}
+		if char.Category == goju.Modifier {
+			return fmt.Errorf("cannot drill %s, which is not read on its own", p.Drill)
+		}
+	}
+	if len(p.availableCharacters()) == 0 {
+		return errors.New("no characters to practise; check the categories, rows and deck")
+	}
+	if p.Words && len(p.availableWords()) == 0 {
+		return errors.New("no example words can be spelled with the characters being practised")
+	}
+	return nil
+}
+
+// availableCharacters returns the characters the session asks about
+func (p *PracticeSession) availableCharacters() []goju.Character {
+	// A drill asks its kana and their look-alikes whatever the other filters
+	if p.Drill != "" {
+		return p.drillCharacters()
+	}
+
+	var candidates []goju.Character
+	if p.Deck != "" {
+		candidates, _ = goju.GetDeck(p.Deck)
+	} else {
+		for _, category := range p.Categories {
+			candidates = append(candidates, goju.GetCharactersByCategory(goju.Category(category))...)
+		}
+	}
+
+	var availableChars []goju.Character
+	for _, char := range candidates {
+		// Iteration marks have no reading of their own
+		if char.Romaji != "" && p.inRows(char) {
+			availableChars = append(availableChars, char)
+		}
+	}
+	return availableChars
+}
+
+// drillCharacters returns the drilled character followed by the characters
+// that look like it in the drill's script
+func (p *PracticeSession) drillCharacters() []goju.Character {
+	char, ok := goju.DefaultRegistry().ByKana(p.Drill)
+	if !ok {
+		return nil
+	}
+	chars := []goju.Character{char}
+	for _, confusable := range goju.SimilarIn(char, p.drillScript()) {
+		chars = append(chars, confusable.Character)
+	}
+	return chars
+}
+
+// drillScript returns the script questions are shown in: that of the
+// drilled kana, so ツ is drilled against シ, and hiragana otherwise
+func (p *PracticeSession) drillScript() goju.Script {
+	if p.Drill != "" && goju.ToHiragana(p.Drill) != p.Drill {
+		return goju.ScriptKatakana
+	}
+	return goju.ScriptHiragana
+}
+
+// Prompt returns the kana the current question asks about, in the script
+// chosen for it
+func (p *PracticeSession) Prompt() string {
+	if p.Current.Word.Kana != "" {
+		return p.Current.Word.Kana
+	}
+	if p.Current.Script == goju.ScriptKatakana {
+		return p.Current.Character.Katakana
+	}
+	return p.Current.Character.Hiragana
+}
+
+// options returns the shuffled choices for the current question: the answer
+// and distractors, preferring the romaji of look-alike characters
+func (p *PracticeSession) options() []string {
+	if p.Choices < 2 {
+		return nil
+	}
+	answer := p.Answer()
+	options := []string{answer}
+	seen := map[string]bool{answer: true}
+	add := func(romaji string) {
+		if romaji != "" && !seen[romaji] && len(options) < p.Choices {
+			seen[romaji] = true
+			options = append(options, romaji)
+		}
+	}
+
+	if p.Current.Word.Kana != "" {
+		words := goju.DefaultRegistry().Words()
+		for _, i := range rand.Perm(len(words)) {
+			add(words[i].RomajiIn(p.System))
+		}
+	} else {
+		// Heavier look-alikes are more likely to be picked
+		similar := p.similar()
+		for len(similar) > 0 && len(options) < p.Choices {
+			pick := weightedPick(similar)
+			add(similar[pick].Character.RomajiIn(p.System))
+			similar = append(similar[:pick], similar[pick+1:]...)
+		}
+		chars := p.availableCharacters()
+		for _, i := range rand.Perm(len(chars)) {
+			add(chars[i].RomajiIn(p.System))
+		}
+	}
+
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+	return options
+}
+
+// similar returns the look-alikes of the current character in the script
+// it is shown in
+func (p *PracticeSession) similar() []goju.Confusable {
+	return goju.SimilarIn(p.Current.Character, p.Current.Script)
+}
+
+// weightedPick returns the position of a random look-alike, chosen in
+// proportion to its weight
+func weightedPick(similar []goju.Confusable) int {
+	var total float64
+	for _, confusable := range similar {
+		total += confusable.Weight
+	}
+	target := rand.Float64() * total
+	for i, confusable := range similar {
+		target -= confusable.Weight
+		if target < 0 {
+			return i
+		}
+	}
+	return len(similar) - 1
+}
+
+// Confusion reports the look-alike of the current character that a wrong
+// answer spells, such as ツ when シ was answered with tsu
+func (p *PracticeSession) Confusion(input string) (goju.Confusable, bool) {
+	input = goju.Normalize(strings.TrimSpace(p.choice(input)))
+	for _, confusable := range p.similar() {
+		if confusable.Character.RomajiIn(p.System) == input {
+			return confusable, true
+		}
+	}
+	return goju.Confusable{}, false
+}
+
+// choice maps the number of a multiple-choice option to its romaji and
+// returns any other input unchanged
+func (p *PracticeSession) choice(input string) string {
+	n, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || n < 1 || n > len(p.Current.Options) {
+		return input
+	}
+	return p.Current.Options[n-1]
+}
+
+// Answer returns the expected romaji for the current question
+func (p *PracticeSession) Answer() string {
+	if p.Current.Word.Kana != "" {
+		return p.Current.Word.RomajiIn(p.System)
+	}
+	return p.Current.Character.RomajiIn(p.System)
+}
+
+// inRows reports whether a character passes the session's row filter
+func (p *PracticeSession) inRows(char goju.Character) bool {
+	if len(p.Rows) == 0 {
+		return true
+	}
+	for _, row := range p.Rows {
+		if char.Row == row {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAnswer checks if the provided answer is correct in the session's
+// romanization system. Ambiguous spellings such as ji also accept the
+// spelling that singles out the kana, so ぢ may be answered with ji or di.
+// The input is normalized, so full-width or upper-case answers count.
+// Words accept the same spellings kana by kana, so はなぢ may be answered
+// with hanaji or hanadi, with or without the apostrophe that separates
+// syllabic n from a following vowel. In multiple-choice sessions the number
+// of an option counts as that option.
 func (p *PracticeSession) CheckAnswer(input string) bool {
-	return input == p.Current.Character.Romaji
+	input = goju.Normalize(strings.TrimSpace(p.choice(input)))
+	if p.Current.Word.Kana != "" {
+		answer := p.Current.Word.RomajiIn(p.System)
+		if input == answer || input == strings.ReplaceAll(answer, "'", "") {
+			return true
+		}
+		return goju.AcceptsRomaji(p.Current.Word.Kana, input, p.System)
+	}
+	for _, accepted := range goju.AcceptedRomaji(p.Current.Character, p.System) {
+		if input == accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// Hint returns the mnemonic for the current character or the meaning of
+// the current word in the session's language, or an empty string if there
+// is none
+func (p *PracticeSession) Hint() string {
+	if p.Current.Word.Kana != "" {
+		return p.Current.Word.Meaning(p.Language)
+	}
+	mnemonic, ok := goju.GetMnemonic(p.Current.Character.ID)
+	if !ok {
+		return ""
+	}
+	return mnemonic.Text(p.Language)
 }
 
 // RecordMistake records a mistake in the current practice session
@@ -83,6 +358,7 @@ func (p *PracticeSession) CompleteQuestion(correct bool) {
 	duration := time.Since(p.Current.StartTime)
 	mistake := Mistake{
 		Character: p.Current.Character,
+		Word:      p.Current.Word,
 		Input:     p.Current.Input,
 		Attempts:  p.Current.Attempts,
 		Correct:   correct,
@@ -118,7 +394,7 @@ func (p *PracticeSession) GetAccuracy() float64 {
 	return float64(currentResult.Correct) / float64(currentResult.Total) * 100
 }
 
-// GetWeaknesses returns the most frequently missed characters
+// GetWeaknesses returns the most frequently missed characters or words
 func (p *PracticeSession) GetWeaknesses(limit int) []Mistake {
 	if len(p.Results) == 0 {
 		return nil
@@ -127,14 +403,14 @@ func (p *PracticeSession) GetWeaknesses(limit int) []Mistake {
 	mistakeCount := make(map[string]int)
 	for _, result := range p.Results {
 		for _, mistake := range result.Mistakes {
-			mistakeCount[mistake.Character.Romaji]++
+			mistakeCount[mistake.key()]++
 		}
 	}
 
 	var weaknesses []Mistake
 	for _, result := range p.Results {
 		for _, mistake := range result.Mistakes {
-			if mistakeCount[mistake.Character.Romaji] >= 2 {
+			if mistakeCount[mistake.key()] >= 2 {
 				weaknesses = append(weaknesses, mistake)
 			}
 		}
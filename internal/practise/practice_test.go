@@ -0,0 +1,272 @@
+package practise
+
+import (
+	"testing"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		rows       []goju.Row
+		words      bool
+		wantErr    bool
+	}{
+		{"Characters", []string{"seion"}, nil, false, false},
+		{"Words in one row", []string{"seion"}, []goju.Row{goju.RowKa}, true, false},
+		{"No words to spell", []string{"handaku"}, nil, true, true},
+		{"Rows outside the categories", []string{"yoon"}, []goju.Row{goju.RowKa}, false, true},
+		{"Drill ignores rows", []string{"seion"}, []goju.Row{goju.RowKa}, false, false},
+		{"Drill of a modifier", []string{"seion"}, nil, false, true},
+		{"Drill of unknown kana", []string{"seion"}, nil, false, true},
+	}
+	drills := map[string]string{
+		"Drill ignores rows":    "シ",
+		"Drill of a modifier":   "っ",
+		"Drill of unknown kana": "x",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewPracticeSession(1, tt.categories)
+			session.Rows = tt.rows
+			session.Words = tt.words
+			session.Drill = drills[tt.name]
+			if err := session.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetNextWordUsesSessionKana(t *testing.T) {
+	session := NewPracticeSession(1, []string{"seion"})
+	session.Rows = []goju.Row{goju.RowKa}
+	for i := 0; i < 20; i++ {
+		word := session.GetNextWord()
+		if word.Kana == "" {
+			t.Fatal("GetNextWord() returned no word")
+		}
+		for _, r := range word.Kana {
+			char, ok := goju.GetCharacterByHiragana(string(r))
+			if !ok || char.Row != goju.RowKa && char.Category != goju.Modifier {
+				t.Errorf("GetNextWord() = %s, which uses %c outside the ka row", word.Kana, r)
+			}
+		}
+	}
+
+	session.Categories = []string{"handaku"}
+	session.Rows = nil
+	if word := session.GetNextWord(); word.Kana != "" {
+		t.Errorf("GetNextWord() = %s, want no word", word.Kana)
+	}
+}
+
+func TestDrillCharacters(t *testing.T) {
+	tests := []struct {
+		drill string
+		rows  []goju.Row
+		want  []string // IDs of the characters asked
+	}{
+		{"バ", nil, []string{"dakuon-ba", "handaku-pa"}},
+		{"ば", nil, []string{"dakuon-ba", "handaku-pa"}},
+		{"シ", []goju.Row{goju.RowKa}, []string{"seion-si", "seion-tu"}},
+		{"し", nil, []string{"seion-si"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.drill, func(t *testing.T) {
+			session := NewPracticeSession(1, []string{"seion"})
+			session.Drill = tt.drill
+			session.Rows = tt.rows
+			chars := session.availableCharacters()
+			if len(chars) != len(tt.want) {
+				t.Fatalf("drilling %s asks %d characters, want %v", tt.drill, len(chars), tt.want)
+			}
+			for i, char := range chars {
+				if char.ID != tt.want[i] {
+					t.Errorf("character %d = %s, want %s", i, char.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNextQuestionScript(t *testing.T) {
+	tests := []struct {
+		name  string
+		drill string
+		want  goju.Script
+	}{
+		{"Hiragana by default", "", goju.ScriptHiragana},
+		{"Hiragana drill", "し", goju.ScriptHiragana},
+		{"Katakana drill", "シ", goju.ScriptKatakana},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewPracticeSession(1, []string{"seion"})
+			session.Drill = tt.drill
+			session.NextQuestion()
+			if session.Current.Script != tt.want {
+				t.Errorf("Current.Script = %v, want %v", session.Current.Script, tt.want)
+			}
+			want := session.Current.Character.Hiragana
+			if tt.want == goju.ScriptKatakana {
+				want = session.Current.Character.Katakana
+			}
+			if session.Prompt() != want || want == "" {
+				t.Errorf("Prompt() = %q, want %q", session.Prompt(), want)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	shi, _ := goju.GetCharacterByHiragana("し")
+	session := NewPracticeSession(1, []string{"seion"})
+	session.Choices = 4
+	session.Current.Character = shi
+	session.Current.Script = goju.ScriptKatakana
+	for i := 0; i < 20; i++ {
+		options := session.options()
+		if len(options) != 4 {
+			t.Fatalf("options() = %v, want 4", options)
+		}
+		seen := make(map[string]bool)
+		for _, option := range options {
+			if seen[option] {
+				t.Errorf("options() = %v repeats %s", options, option)
+			}
+			seen[option] = true
+		}
+		// ツ is the only look-alike of シ, so it is always offered
+		if !seen["shi"] || !seen["tsu"] {
+			t.Errorf("options() = %v, want shi and tsu", options)
+		}
+	}
+
+	// A drill offers only the kana it asks
+	session.Drill = "シ"
+	session.NextQuestion()
+	if len(session.Current.Options) != 2 {
+		t.Errorf("drill Options = %v, want shi and tsu", session.Current.Options)
+	}
+
+	session.Choices = 0
+	session.NextQuestion()
+	if session.Current.Options != nil {
+		t.Errorf("Options = %v without Choices, want none", session.Current.Options)
+	}
+}
+
+func TestWeightedPick(t *testing.T) {
+	tests := []struct {
+		weights []float64
+		want    int
+	}{
+		{[]float64{1}, 0},
+		{[]float64{1, 0}, 0},
+		{[]float64{0, 1}, 1},
+		{[]float64{0, 0, 1}, 2},
+	}
+
+	for _, tt := range tests {
+		similar := make([]goju.Confusable, len(tt.weights))
+		for i, weight := range tt.weights {
+			similar[i].Weight = weight
+		}
+		for i := 0; i < 20; i++ {
+			if got := weightedPick(similar); got != tt.want {
+				t.Errorf("weightedPick(%v) = %d, want %d", tt.weights, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestChoice(t *testing.T) {
+	session := NewPracticeSession(1, []string{"seion"})
+	session.Current.Options = []string{"shi", "tsu", "so"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1", "shi"},
+		{" 3 ", "so"},
+		{"0", "0"},
+		{"4", "4"},
+		{"tsu", "tsu"},
+	}
+
+	for _, tt := range tests {
+		if got := session.choice(tt.input); got != tt.want {
+			t.Errorf("choice(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestConfusion(t *testing.T) {
+	shi, _ := goju.GetCharacterByHiragana("し")
+	tests := []struct {
+		name   string
+		script goju.Script
+		input  string
+		want   string // kana of the look-alike, empty for none
+	}{
+		{"Katakana look-alike", goju.ScriptKatakana, "tsu", "ツ"},
+		{"Numbered option", goju.ScriptKatakana, "2", "ツ"},
+		{"No hiragana look-alike", goju.ScriptHiragana, "tsu", ""},
+		{"Unrelated answer", goju.ScriptKatakana, "ka", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewPracticeSession(1, []string{"seion"})
+			session.Current.Character = shi
+			session.Current.Script = tt.script
+			session.Current.Options = []string{"shi", "tsu"}
+			confusable, ok := session.Confusion(tt.input)
+			if ok != (tt.want != "") || ok && confusable.Kana() != tt.want {
+				t.Errorf("Confusion(%q) = %v, %v, want %q", tt.input, confusable.Kana(), ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		kana   string // a word when longer than one kana
+		system goju.RomanizationSystem
+		input  string
+		want   bool
+	}{
+		{"Character", "し", goju.Hepburn, "shi", true},
+		{"Character in another system", "し", goju.Hepburn, "si", false},
+		{"Ambiguous character", "ぢ", goju.Hepburn, "di", true},
+		{"Word", "ねこ", goju.Hepburn, "neko", true},
+		{"Word in capitals", "ねこ", goju.Hepburn, "NEKO", true},
+		{"Word with an ambiguous kana", "はなぢ", goju.Hepburn, "hanadi", true},
+		{"Word spelled as another kana", "はなぢ", goju.Hepburn, "hanazi", true},
+		{"Word in another system", "ちず", goju.Kunrei, "tizu", true},
+		{"Word without the apostrophe", "ほんや", goju.Hepburn, "honya", true},
+		{"Word with a wrong kana", "ねこ", goju.Hepburn, "neka", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewPracticeSession(1, []string{"seion"})
+			session.System = tt.system
+			if char, ok := goju.GetCharacterByHiragana(tt.kana); ok {
+				session.Current.Character = char
+			} else {
+				session.Current.Word = goju.Word{Kana: tt.kana}
+			}
+			if got := session.CheckAnswer(tt.input); got != tt.want {
+				t.Errorf("CheckAnswer(%q) for %s = %v, want %v", tt.input, tt.kana, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package speech
+
+import (
+	"fmt"
+	"strings"
+)
+
+// phone is one sound of an IPA transcription
+type phone struct {
+	symbol    string // base symbol such as "k", "tɕ" or "a"
+	long      bool   // followed by ː
+	voiceless bool   // a vowel marked with a ring below
+	palatal   bool   // followed by ʲ
+	labial    bool   // followed by ʷ
+}
+
+// vowel reports whether the phone is a vowel
+func (p phone) vowel() bool {
+	_, ok := vowels[p.symbol]
+	return ok
+}
+
+// formants are the first three resonances of the vocal tract in Hz
+type formants [3]float64
+
+// vowels holds the formants of the five Japanese vowels
+var vowels = map[string]formants{
+	"a": {800, 1250, 2600},
+	"i": {300, 2300, 3000},
+	"ɯ": {350, 1350, 2400},
+	"e": {480, 1950, 2600},
+	"o": {480, 900, 2500},
+}
+
+// manner is how a consonant is produced
+type manner int
+
+const (
+	stop manner = iota
+	affricate
+	fricative
+	nasal
+	approximant
+	flap
+	glottal
+)
+
+// consonant describes how to render a consonant
+type consonant struct {
+	manner   manner
+	voiced   bool
+	noise    float64  // centre of the burst or frication noise in Hz
+	formants formants // resonances of nasals, approximants and flaps
+}
+
+// consonants holds every consonant the IPA transcriber produces
+var consonants = map[string]consonant{
+	"p":  {manner: stop, noise: 800},
+	"b":  {manner: stop, voiced: true, noise: 800},
+	"t":  {manner: stop, noise: 4000},
+	"d":  {manner: stop, voiced: true, noise: 4000},
+	"k":  {manner: stop, noise: 2000},
+	"ɡ":  {manner: stop, voiced: true, noise: 2000},
+	"ts": {manner: affricate, noise: 6000},
+	"dz": {manner: affricate, voiced: true, noise: 6000},
+	"tɕ": {manner: affricate, noise: 4000},
+	"dʑ": {manner: affricate, voiced: true, noise: 4000},
+	"s":  {manner: fricative, noise: 6000},
+	"ɕ":  {manner: fricative, noise: 4000},
+	"ç":  {manner: fricative, noise: 3500},
+	"h":  {manner: fricative, noise: 1500},
+	"ɸ":  {manner: fricative, noise: 1200},
+	"m":  {manner: nasal, voiced: true, formants: formants{250, 1100, 2200}},
+	"n":  {manner: nasal, voiced: true, formants: formants{250, 1700, 2600}},
+	"ɲ":  {manner: nasal, voiced: true, formants: formants{250, 2100, 2900}},
+	"ŋ":  {manner: nasal, voiced: true, formants: formants{250, 2300, 2800}},
+	"ɴ":  {manner: nasal, voiced: true, formants: formants{250, 1000, 2400}},
+	"j":  {manner: approximant, voiced: true, formants: formants{280, 2250, 3000}},
+	"w":  {manner: approximant, voiced: true, formants: formants{320, 750, 2400}},
+	"ɾ":  {manner: flap, voiced: true, formants: formants{350, 1300, 2500}},
+	"ʔ":  {manner: glottal},
+}
+
+// parsePhones splits an IPA transcription into phones, matching the
+// two-letter affricates first
+func parsePhones(ipa string) ([]phone, error) {
+	var phones []phone
+	runes := []rune(ipa)
+	for i := 0; i < len(runes); {
+		if i+1 < len(runes) {
+			if _, ok := consonants[string(runes[i:i+2])]; ok {
+				phones = append(phones, phone{symbol: string(runes[i : i+2])})
+				i += 2
+				continue
+			}
+		}
+
+		symbol := string(runes[i])
+		_, isVowel := vowels[symbol]
+		_, isConsonant := consonants[symbol]
+		last := len(phones) - 1
+		switch {
+		case isVowel || isConsonant:
+			phones = append(phones, phone{symbol: symbol})
+		case symbol == "ː" && last >= 0:
+			phones[last].long = true
+		case symbol == "̥" && last >= 0:
+			phones[last].voiceless = true
+		case symbol == "ʲ" && last >= 0:
+			phones[last].palatal = true
+		case symbol == "ʷ" && last >= 0:
+			phones[last].labial = true
+		default:
+			return nil, fmt.Errorf("cannot pronounce %q in [%s]", symbol, strings.TrimSpace(ipa))
+		}
+		i++
+	}
+	return phones, nil
+}
@@ -0,0 +1,139 @@
+package speech
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestSynthesizeLength(t *testing.T) {
+	s := NewSynthesizer()
+	mora := s.samples(s.MoraLength)
+	pause := s.samples(s.Pause)
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"あ", mora},
+		{"あいう", 3 * mora},
+		{"かさたな", 4 * mora},
+		{"キャット", 3 * mora}, // っ holds a mora
+		{"ラーメン", 4 * mora}, // so do ー and ン
+		{"がっこう", 4 * mora}, // こう is a long vowel
+		{"あっ", mora},       // a final っ is a silent glottal stop
+		{"あっあ", 3 * mora},  // and holds its mora before a vowel
+		{"げんいん", 4 * mora}, // ん before a vowel holds its mora
+		{"れんあい", 4 * mora},
+		{"ねこ、いぬ", 4*mora + pause},    // punctuation pauses
+		{" ねこ  いぬ。", 4*mora + pause}, // leading and trailing pauses are dropped
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			samples, err := s.Synthesize(tt.text)
+			if err != nil {
+				t.Fatalf("Synthesize(%q) error: %v", tt.text, err)
+			}
+			if diff := len(samples) - tt.want; diff < -4 || diff > 4 {
+				t.Errorf("Synthesize(%q) gave %d samples, want about %d", tt.text, len(samples), tt.want)
+			}
+		})
+	}
+}
+
+func TestSynthesizeDeterministic(t *testing.T) {
+	s := NewSynthesizer()
+	first, err := s.Synthesize("しんぶん、きっぷ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := s.Synthesize("しんぶん、きっぷ")
+	if len(first) != len(second) {
+		t.Fatalf("lengths differ: %d and %d", len(first), len(second))
+	}
+	loudest := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("sample %d differs: %d and %d", i, first[i], second[i])
+		}
+		if first[i] > int16(loudest) {
+			loudest = int(first[i])
+		}
+	}
+	if loudest < 20000 {
+		t.Errorf("loudest sample is %d, want it normalized near full scale", loudest)
+	}
+}
+
+func TestSynthesizeErrors(t *testing.T) {
+	s := NewSynthesizer()
+	for _, text := range []string{"", "、。", "neko", "ねこ猫", "nいぬ"} {
+		if _, err := s.Synthesize(text); err == nil {
+			t.Errorf("Synthesize(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestParsePhones(t *testing.T) {
+	phones, err := parsePhones("kʲi̥tːɕaɴ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []phone{
+		{symbol: "k", palatal: true},
+		{symbol: "i", voiceless: true},
+		{symbol: "t", long: true},
+		{symbol: "ɕ"},
+		{symbol: "a"},
+		{symbol: "ɴ"},
+	}
+	if len(phones) != len(want) {
+		t.Fatalf("parsePhones() = %+v, want %+v", phones, want)
+	}
+	for i := range want {
+		if phones[i] != want[i] {
+			t.Errorf("phone %d = %+v, want %+v", i, phones[i], want[i])
+		}
+	}
+
+	if _, err := parsePhones("ka?"); err == nil {
+		t.Error("parsePhones(ka?) succeeded, want an error")
+	}
+}
+
+func TestWriteWAV(t *testing.T) {
+	var buf bytes.Buffer
+	samples := []int16{0, 1000, -1000, 32767}
+	if err := WriteWAV(&buf, samples, 22050); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if len(data) != 44+2*len(samples) {
+		t.Fatalf("file is %d bytes, want %d", len(data), 44+2*len(samples))
+	}
+
+	le := binary.LittleEndian
+	checks := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"RIFF", string(data[0:4]), "RIFF"},
+		{"WAVE", string(data[8:12]), "WAVE"},
+		{"riff size", le.Uint32(data[4:8]), uint32(36 + 2*len(samples))},
+		{"format", le.Uint16(data[20:22]), uint16(1)},
+		{"channels", le.Uint16(data[22:24]), uint16(1)},
+		{"sample rate", le.Uint32(data[24:28]), uint32(22050)},
+		{"byte rate", le.Uint32(data[28:32]), uint32(44100)},
+		{"bits", le.Uint16(data[34:36]), uint16(16)},
+		{"data", string(data[36:40]), "data"},
+		{"data size", le.Uint32(data[40:44]), uint32(2 * len(samples))},
+		{"last sample", int16(le.Uint16(data[50:52])), int16(32767)},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
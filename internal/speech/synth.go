@@ -0,0 +1,354 @@
+// Package speech renders kana to audio with a small formant synthesizer.
+// It needs no voice data or audio device: every sound is built from a
+// pulse train and noise shaped by resonators, so the same text always
+// gives the same samples.
+package speech
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+// pauseMarks are the characters besides spaces that make a pause
+const pauseMarks = "、。，．,.!?！？・…"
+
+// Onset durations of each kind of consonant
+const (
+	closureLength   = 40 * time.Millisecond
+	burstLength     = 15 * time.Millisecond
+	fricationLength = 90 * time.Millisecond
+	nasalLength     = 50 * time.Millisecond
+	glideLength     = 35 * time.Millisecond
+	flapLength      = 20 * time.Millisecond
+	fadeLength      = 4 * time.Millisecond
+)
+
+// Synthesizer turns kana into 16-bit mono samples
+type Synthesizer struct {
+	SampleRate int           // samples per second
+	Pitch      float64       // pitch at the start of a phrase in Hz, falling by a fifth towards the end
+	MoraLength time.Duration // length of one mora
+	Pause      time.Duration // silence for a space or punctuation
+}
+
+// NewSynthesizer returns a synthesizer with a low voice at a careful
+// speaking rate
+func NewSynthesizer() *Synthesizer {
+	return &Synthesizer{
+		SampleRate: 22050,
+		Pitch:      140,
+		MoraLength: 150 * time.Millisecond,
+		Pause:      250 * time.Millisecond,
+	}
+}
+
+// segment is a stretch of sound with steady settings
+type segment struct {
+	length   time.Duration
+	voice    float64  // loudness of the pulse train
+	noise    float64  // loudness of the noise
+	formants formants // resonances of the pulse train, and of the noise when noiseAt is 0
+	noiseAt  float64  // centre of the noise in Hz
+}
+
+// Synthesize renders a kana string. Spaces and punctuation become pauses;
+// anything else that is not kana is an error.
+func (s *Synthesizer) Synthesize(text string) ([]int16, error) {
+	var segments []segment
+	spoken := false
+	for _, run := range splitPauses(goju.Normalize(text)) {
+		if run == "" {
+			if spoken {
+				segments = append(segments, segment{length: s.Pause})
+			}
+			continue
+		}
+		// Text that is not kana passes through IPA unchanged, so it is
+		// caught here; kana with no romaji, such as a final っ, are spoken
+		if text, ok := notKana(run); ok {
+			return nil, fmt.Errorf("cannot speak %q: %q is not kana", run, text)
+		}
+		phones, err := parsePhones(goju.IPA(run))
+		if err != nil {
+			return nil, fmt.Errorf("cannot speak %q: %w", run, err)
+		}
+		segments = append(segments, s.plan(phones)...)
+		spoken = true
+	}
+	if !spoken {
+		return nil, errors.New("no kana to speak")
+	}
+	// A trailing pause only pads the file
+	if last := len(segments) - 1; segments[last].voice == 0 && segments[last].noise == 0 {
+		segments = segments[:last]
+	}
+	return s.render(segments), nil
+}
+
+// notKana returns the first stretch of run that is not part of any mora
+func notKana(run string) (string, bool) {
+	pos := 0
+	for _, mora := range goju.Morae(run) {
+		if mora.Start != pos {
+			return run[pos:mora.Start], true
+		}
+		pos = mora.End
+	}
+	if pos != len(run) {
+		return run[pos:], true
+	}
+	return "", false
+}
+
+// splitPauses splits text into runs of kana, with an empty string for each
+// group of pause characters between them
+func splitPauses(text string) []string {
+	var runs []string
+	var sb strings.Builder
+	paused := false
+	for _, r := range text {
+		if unicode.IsSpace(r) || strings.ContainsRune(pauseMarks, r) {
+			if sb.Len() > 0 {
+				runs = append(runs, sb.String())
+				sb.Reset()
+			}
+			if !paused {
+				runs = append(runs, "")
+				paused = true
+			}
+			continue
+		}
+		sb.WriteRune(r)
+		paused = false
+	}
+	if sb.Len() > 0 {
+		runs = append(runs, sb.String())
+	}
+	return runs
+}
+
+// plan lays out the segments of a run of phones. Every mora lasts
+// MoraLength: a consonant before a vowel takes its time from the vowel,
+// while a consonant that closes a mora (a doubled consonant, ん or っ) and
+// the ː of a long vowel take a whole mora of their own. ɴ and ʔ are only
+// ever ん and っ, so they close a mora even before a vowel, as in げんいん.
+func (s *Synthesizer) plan(phones []phone) []segment {
+	var segments []segment
+	var onset time.Duration
+	for i, p := range phones {
+		if f, ok := vowels[p.symbol]; ok {
+			length := s.MoraLength - onset
+			if length < s.MoraLength*2/5 {
+				length = s.MoraLength * 2 / 5
+			}
+			if p.long {
+				length += s.MoraLength
+			}
+			vowel := segment{length: length, voice: 1, formants: f}
+			if p.voiceless {
+				vowel = segment{length: length, noise: 0.3, formants: f}
+			}
+			segments = append(segments, vowel)
+			onset = 0
+			continue
+		}
+
+		c := consonants[p.symbol]
+		if moraic[p.symbol] || i+1 == len(phones) || !phones[i+1].vowel() {
+			segments = append(segments, coda(c, s.MoraLength))
+			continue
+		}
+		sounds := articulate(c, vowels[phones[i+1].symbol])
+		switch {
+		case p.palatal:
+			sounds = append(sounds, segment{length: glideLength, voice: 0.7, formants: consonants["j"].formants})
+		case p.labial:
+			sounds = append(sounds, segment{length: glideLength, voice: 0.7, formants: consonants["w"].formants})
+		}
+		for _, sound := range sounds {
+			onset += sound.length
+		}
+		segments = append(segments, sounds...)
+	}
+	return segments
+}
+
+// moraic holds the consonants that are a mora by themselves
+var moraic = map[string]bool{"ɴ": true, "ʔ": true}
+
+// articulate returns the segments of a consonant before a vowel
+func articulate(c consonant, vowel formants) []segment {
+	closure := segment{length: closureLength}
+	if c.voiced {
+		closure = segment{length: closureLength, voice: 0.15, formants: formants{250, 1000, 2500}}
+	}
+	switch c.manner {
+	case stop:
+		return []segment{closure, {length: burstLength, noise: 0.8, noiseAt: c.noise}}
+	case affricate:
+		closure.length = closureLength * 3 / 4
+		frication := segment{length: fricationLength * 2 / 3, noise: 0.6, noiseAt: c.noise}
+		if c.voiced {
+			frication.voice, frication.formants = 0.3, vowel
+		}
+		return []segment{closure, frication}
+	case fricative:
+		if c.noise < 2000 {
+			// h and ɸ are breathy and take their colour from the vowel
+			return []segment{{length: fricationLength, noise: 0.4, formants: vowel}}
+		}
+		return []segment{{length: fricationLength, noise: 0.6, noiseAt: c.noise}}
+	case nasal:
+		return []segment{{length: nasalLength, voice: 0.6, formants: c.formants}}
+	case approximant:
+		return []segment{{length: glideLength, voice: 0.7, formants: c.formants}}
+	case flap:
+		return []segment{{length: flapLength, voice: 0.5, formants: c.formants}}
+	}
+	return nil
+}
+
+// coda returns the segment of a consonant that closes a mora: held noise
+// for a fricative, a hum for a nasal and silence for anything else
+func coda(c consonant, length time.Duration) segment {
+	switch c.manner {
+	case fricative:
+		return segment{length: length, noise: 0.6, noiseAt: c.noise}
+	case nasal:
+		return segment{length: length, voice: 0.6, formants: c.formants}
+	}
+	return segment{length: length}
+}
+
+// render turns segments into samples with the pitch falling across them,
+// scaled so the loudest sample sits just below full scale
+func (s *Synthesizer) render(segments []segment) []int16 {
+	rate := float64(s.SampleRate)
+	total := 0
+	for _, seg := range segments {
+		total += s.samples(seg.length)
+	}
+
+	out := make([]float64, 0, total)
+	noise := newNoise()
+	phase := 0.0
+	fade := s.samples(fadeLength)
+	for _, seg := range segments {
+		n := s.samples(seg.length)
+		if seg.voice == 0 && seg.noise == 0 {
+			out = append(out, make([]float64, n)...)
+			continue
+		}
+		voiced := newCascade(seg.formants, rate)
+		shaped := newCascade(seg.formants, rate)
+		if seg.noiseAt > 0 {
+			shaped = cascade{newResonator(seg.noiseAt, seg.noiseAt/2, rate)}
+		}
+		for i := 0; i < n; i++ {
+			pitch := s.Pitch * (1 - float64(len(out))/float64(total)/3)
+			phase += pitch / rate
+			pulse := 0.0
+			if phase >= 1 {
+				phase--
+				pulse = 1
+			}
+
+			sample := 0.0
+			if seg.voice > 0 {
+				sample += seg.voice * voiced.filter(pulse)
+			}
+			if seg.noise > 0 {
+				sample += seg.noise * shaped.filter(noise.next()) / 8
+			}
+			if edge := min(i, n-1-i); edge < fade {
+				sample *= float64(edge) / float64(fade)
+			}
+			out = append(out, sample)
+		}
+	}
+
+	peak := 0.0
+	for _, sample := range out {
+		peak = math.Max(peak, math.Abs(sample))
+	}
+	samples := make([]int16, len(out))
+	if peak == 0 {
+		return samples
+	}
+	for i, sample := range out {
+		samples[i] = int16(math.Round(sample / peak * 0.8 * math.MaxInt16))
+	}
+	return samples
+}
+
+// samples returns the number of samples in a length of time
+func (s *Synthesizer) samples(length time.Duration) int {
+	return int(math.Round(length.Seconds() * float64(s.SampleRate)))
+}
+
+// resonator is a two-pole filter that rings at one frequency
+type resonator struct {
+	a, b, c float64
+	y1, y2  float64
+}
+
+// newResonator returns a resonator with unity gain at zero frequency
+func newResonator(frequency, bandwidth, rate float64) resonator {
+	r := math.Exp(-math.Pi * bandwidth / rate)
+	c := -r * r
+	b := 2 * r * math.Cos(2*math.Pi*frequency/rate)
+	return resonator{a: 1 - b - c, b: b, c: c}
+}
+
+func (r *resonator) filter(x float64) float64 {
+	y := r.a*x + r.b*r.y1 + r.c*r.y2
+	r.y2, r.y1 = r.y1, y
+	return y
+}
+
+// cascade is a chain of resonators, one per formant
+type cascade []resonator
+
+// formantBandwidths are the bandwidths of the first three formants in Hz
+var formantBandwidths = [3]float64{80, 100, 150}
+
+func newCascade(f formants, rate float64) cascade {
+	var chain cascade
+	for i, frequency := range f {
+		if frequency > 0 {
+			chain = append(chain, newResonator(frequency, formantBandwidths[i], rate))
+		}
+	}
+	return chain
+}
+
+func (c cascade) filter(x float64) float64 {
+	for i := range c {
+		x = c[i].filter(x)
+	}
+	return x
+}
+
+// noise is a xorshift generator with a fixed seed, so renders repeat exactly
+type noise uint32
+
+func newNoise() *noise {
+	n := noise(2463534242)
+	return &n
+}
+
+// next returns a value in [-1, 1)
+func (n *noise) next() float64 {
+	x := uint32(*n)
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	*n = noise(x)
+	return float64(x)/(1<<31) - 1
+}
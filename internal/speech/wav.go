@@ -0,0 +1,39 @@
+package speech
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// WriteWAV writes samples as a 16-bit mono PCM WAV file
+func WriteWAV(w io.Writer, samples []int16, sampleRate int) error {
+	const (
+		channels      = 1
+		bitsPerSample = 16
+		headerSize    = 36
+	)
+	dataSize := len(samples) * 2
+	blockAlign := channels * bitsPerSample / 8
+
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'},
+		uint32(headerSize + dataSize),
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16), // size of the fmt chunk
+		uint16(1),  // PCM
+		uint16(channels),
+		uint32(sampleRate),
+		uint32(sampleRate * blockAlign),
+		uint16(blockAlign),
+		uint16(bitsPerSample),
+		[4]byte{'d', 'a', 't', 'a'},
+		uint32(dataSize),
+	}
+	for _, field := range header {
+		if err := binary.Write(w, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+	return binary.Write(w, binary.LittleEndian, samples)
+}
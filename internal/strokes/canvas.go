@@ -0,0 +1,159 @@
+package strokes
+
+import (
+	"strings"
+)
+
+// Canvas is a grid of dots drawn with terminal characters
+type Canvas interface {
+	// Size returns the width and height in dots
+	Size() (width, height int)
+	// Set turns on the dot at x, y; dots outside the canvas are ignored
+	Set(x, y int)
+	// Clear turns off every dot
+	Clear()
+	// String renders the canvas, one line per row of characters
+	String() string
+}
+
+// brailleDots maps a dot position within a 2x4 braille cell to its bit
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// BrailleCanvas packs 2x4 dots into each braille character, giving the
+// finest resolution a terminal can show
+type BrailleCanvas struct {
+	cols, rows int
+	cells      []rune
+}
+
+// NewBrailleCanvas creates a canvas cols characters wide and rows tall
+func NewBrailleCanvas(cols, rows int) *BrailleCanvas {
+	return &BrailleCanvas{cols: cols, rows: rows, cells: make([]rune, cols*rows)}
+}
+
+// Size implements Canvas
+func (c *BrailleCanvas) Size() (int, int) {
+	return c.cols * 2, c.rows * 4
+}
+
+// Set implements Canvas
+func (c *BrailleCanvas) Set(x, y int) {
+	if x < 0 || y < 0 || x >= c.cols*2 || y >= c.rows*4 {
+		return
+	}
+	c.cells[(y/4)*c.cols+x/2] |= brailleDots[y%4][x%2]
+}
+
+// Clear implements Canvas
+func (c *BrailleCanvas) Clear() {
+	for i := range c.cells {
+		c.cells[i] = 0
+	}
+}
+
+// String implements Canvas
+func (c *BrailleCanvas) String() string {
+	var sb strings.Builder
+	for row := 0; row < c.rows; row++ {
+		for col := 0; col < c.cols; col++ {
+			sb.WriteRune(0x2800 + c.cells[row*c.cols+col])
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// BlockCanvas packs two dots, one above the other, into each character
+// using half blocks, for fonts without braille
+type BlockCanvas struct {
+	cols, rows int
+	dots       []bool
+}
+
+// NewBlockCanvas creates a canvas cols characters wide and rows tall
+func NewBlockCanvas(cols, rows int) *BlockCanvas {
+	return &BlockCanvas{cols: cols, rows: rows, dots: make([]bool, cols*rows*2)}
+}
+
+// Size implements Canvas
+func (c *BlockCanvas) Size() (int, int) {
+	return c.cols, c.rows * 2
+}
+
+// Set implements Canvas
+func (c *BlockCanvas) Set(x, y int) {
+	if x < 0 || y < 0 || x >= c.cols || y >= c.rows*2 {
+		return
+	}
+	c.dots[y*c.cols+x] = true
+}
+
+// Clear implements Canvas
+func (c *BlockCanvas) Clear() {
+	for i := range c.dots {
+		c.dots[i] = false
+	}
+}
+
+// String implements Canvas
+func (c *BlockCanvas) String() string {
+	var sb strings.Builder
+	for row := 0; row < c.rows; row++ {
+		for col := 0; col < c.cols; col++ {
+			top := c.dots[(row*2)*c.cols+col]
+			bottom := c.dots[(row*2+1)*c.cols+col]
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+			case top:
+				sb.WriteRune('▀')
+			case bottom:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteRune(' ')
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// line turns on the dots between two points with Bresenham's algorithm
+func line(c Canvas, x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.Set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
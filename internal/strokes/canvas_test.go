@@ -0,0 +1,95 @@
+package strokes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+func TestBrailleCanvas(t *testing.T) {
+	c := NewBrailleCanvas(2, 1)
+	c.Set(0, 0)
+	c.Set(1, 3)
+	c.Set(3, 1)
+	c.Set(10, 10) // outside, ignored
+
+	if got, want := c.String(), "⢁⠐\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	c.Clear()
+	if got, want := c.String(), "⠀⠀\n"; got != want {
+		t.Errorf("String() after Clear() = %q, want %q", got, want)
+	}
+}
+
+func TestBlockCanvas(t *testing.T) {
+	c := NewBlockCanvas(3, 1)
+	c.Set(0, 0)
+	c.Set(1, 1)
+	c.Set(2, 0)
+	c.Set(2, 1)
+
+	if got, want := c.String(), "▀▄█\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		strokes []goju.Stroke
+		want    string
+	}{
+		{"Horizontal", []goju.Stroke{{{X: 0, Y: 0}, {X: 100, Y: 0}}}, "▀▀▀▀\n    \n"},
+		{"Vertical", []goju.Stroke{{{X: 0, Y: 0}, {X: 0, Y: 100}}}, "█   \n█   \n"},
+		{"Diagonal", []goju.Stroke{{{X: 0, Y: 0}, {X: 100, Y: 100}}}, "▀▄  \n  ▀▄\n"},
+		{"Single point", []goju.Stroke{{{X: 100, Y: 100}}}, "    \n   ▄\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(NewBlockCanvas(4, 2), tt.strokes); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrokeFrames(t *testing.T) {
+	strokes, _ := goju.GetStrokes("あ")
+	c, err := NewCanvas(Braille, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames := StrokeFrames(c, strokes)
+	if len(frames) != 3 {
+		t.Fatalf("StrokeFrames(あ) = %d frames, want 3", len(frames))
+	}
+	for i := 1; i < len(frames); i++ {
+		if dots(frames[i]) <= dots(frames[i-1]) {
+			t.Errorf("frame %d does not add to frame %d", i+1, i)
+		}
+	}
+
+	if _, err := NewCanvas("ascii", 32); err == nil {
+		t.Error("NewCanvas(ascii) error = nil, want error")
+	}
+	for _, size := range []int{0, -8, 3} {
+		if _, err := NewCanvas(Block, size); err == nil {
+			t.Errorf("NewCanvas(block, %d) error = nil, want error", size)
+		}
+	}
+}
+
+// dots counts the raised braille dots in a frame
+func dots(frame string) int {
+	n := 0
+	for _, r := range strings.ReplaceAll(frame, "\n", "") {
+		for bits := r - 0x2800; bits > 0; bits >>= 1 {
+			n += int(bits & 1)
+		}
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package strokes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/make17better/goju/pkg/goju"
+)
+
+// Mode selects the characters a canvas is drawn with
+type Mode string
+
+const (
+	Braille Mode = "braille"
+	Block   Mode = "block"
+)
+
+// minSize is the smallest canvas size, one braille cell tall
+const minSize = 4
+
+// NewCanvas creates a square canvas of about size dots in the given mode.
+// Terminal cells are twice as tall as they are wide, so both modes use
+// twice as many rows of dots per character as columns.
+func NewCanvas(mode Mode, size int) (Canvas, error) {
+	if size < minSize {
+		return nil, fmt.Errorf("canvas size %d is too small (want at least %d)", size, minSize)
+	}
+	switch mode {
+	case Braille:
+		return NewBrailleCanvas(size/2, size/4), nil
+	case Block:
+		return NewBlockCanvas(size, size/2), nil
+	}
+	return nil, fmt.Errorf("unknown canvas mode %q (want braille or block)", mode)
+}
+
+// scale maps a stroke coordinate onto n dots
+func scale(v, n int) int {
+	return v * (n - 1) / goju.StrokeBox
+}
+
+// Draw draws strokes onto the canvas, scaled to fill it
+func Draw(c Canvas, strokes []goju.Stroke) {
+	width, height := c.Size()
+	for _, stroke := range strokes {
+		if len(stroke) == 1 {
+			c.Set(scale(stroke[0].X, width), scale(stroke[0].Y, height))
+			continue
+		}
+		for i := 1; i < len(stroke); i++ {
+			from, to := stroke[i-1], stroke[i]
+			line(c, scale(from.X, width), scale(from.Y, height), scale(to.X, width), scale(to.Y, height))
+		}
+	}
+}
+
+// Render returns the strokes drawn on a cleared canvas
+func Render(c Canvas, strokes []goju.Stroke) string {
+	c.Clear()
+	Draw(c, strokes)
+	return c.String()
+}
+
+// StrokeFrames returns one frame per stroke, each showing the kana with
+// that stroke and every earlier one complete
+func StrokeFrames(c Canvas, strokes []goju.Stroke) []string {
+	frames := make([]string, len(strokes))
+	for i := range strokes {
+		frames[i] = Render(c, strokes[:i+1])
+	}
+	return frames
+}
+
+// Animate draws the strokes of kana segment by segment, redrawing the
+// canvas in place on a terminal and pausing delay between segments
+func Animate(w io.Writer, c Canvas, kana string, strokes []goju.Stroke, delay time.Duration) error {
+	stepper := goju.NewStrokeStepper(strokes)
+	lines := 0
+	for stepper.Next() {
+		frame := fmt.Sprintf("%s  stroke %d/%d\n%s", kana, stepper.Stroke(), stepper.Total(), Render(c, stepper.Drawn()))
+		if lines > 0 {
+			// Move the cursor back to the top of the previous frame
+			if _, err := fmt.Fprintf(w, "\x1b[%dA", lines); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, frame); err != nil {
+			return err
+		}
+		lines = strings.Count(frame, "\n")
+		time.Sleep(delay)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/make17better/goju/internal/config"
 	"github.com/make17better/goju/internal/learn"
 	"github.com/make17better/goju/internal/practise"
+	"github.com/make17better/goju/internal/strokes"
+	"github.com/make17better/goju/pkg/goju"
 	"github.com/rivo/tview"
 )
 
@@ -112,6 +114,18 @@ func (t *TUI) showLearnMenu() {
 		AddItem("Hard (五十音)", "All sounds", 'h', func() {
 			t.showLearningContent(learn.Hard)
 		}).
+		AddItem("Chart (五十音图)", "Rows and columns of the table", 'c', func() {
+			t.showChart()
+		}).
+		AddItem("Origins (字源)", "Kana grouped by source character", 'o', func() {
+			t.showOrigins()
+		}).
+		AddItem("Voicing (浊音化)", "Kana beside their dakuten and handakuten forms", 'v', func() {
+			t.showVoicing()
+		}).
+		AddItem("Stroke order (笔顺)", "Watch how a kana is written", 's', func() {
+			t.showStrokes()
+		}).
 		AddItem("Back", "Return to main menu", 'b', func() {
 			t.pages.SwitchToPage("main")
 		})
@@ -123,7 +137,14 @@ func (t *TUI) showLearnMenu() {
 
 // showLearningContent displays the learning content
 func (t *TUI) showLearningContent(difficulty learn.Difficulty) {
+	system, err := t.config.RomanizationSystem()
+	if err != nil {
+		t.showError(err, "learn")
+		return
+	}
 	content := learn.GetLearningContent(difficulty, "both")
+	content.System = system
+	content.Language = t.config.Language
 	text := tview.NewTextView().
 		SetText(learn.FormatLearningContent(content, "both")).
 		SetScrollable(true)
@@ -141,6 +162,99 @@ func (t *TUI) showLearningContent(difficulty learn.Difficulty) {
 	t.pages.SwitchToPage("content")
 }
 
+// showChart displays the gojūon chart
+func (t *TUI) showChart() {
+	system, err := t.config.RomanizationSystem()
+	if err != nil {
+		t.showError(err, "learn")
+		return
+	}
+	t.showReference("chart", learn.FormatChart("hiragana", system))
+}
+
+// showOrigins displays the kana grouped by origin
+func (t *TUI) showOrigins() {
+	t.showReference("origins", learn.FormatOrigins())
+}
+
+// showVoicing displays the voicing table
+func (t *TUI) showVoicing() {
+	system, err := t.config.RomanizationSystem()
+	if err != nil {
+		t.showError(err, "learn")
+		return
+	}
+	t.showReference("voicing", learn.FormatVoicing("hiragana", system))
+}
+
+// showReference displays a scrollable reference page from the learn menu
+func (t *TUI) showReference(name, content string) {
+	text := tview.NewTextView().
+		SetText(content).
+		SetScrollable(true)
+
+	backButton := tview.NewButton("Back").SetSelectedFunc(func() {
+		t.pages.SwitchToPage("learn")
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, false).
+		AddItem(backButton, 1, 0, true)
+
+	t.pages.AddPage(name, layout, true, false)
+	t.pages.SwitchToPage(name)
+}
+
+// showStrokes animates the stroke order of a kana typed by the user
+func (t *TUI) showStrokes() {
+	drawing := tview.NewTextView()
+	input := tview.NewInputField().SetLabel("Kana: ")
+	animation := 0
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			animation++
+			t.pages.SwitchToPage("learn")
+		case tcell.KeyEnter:
+			text := []rune(goju.Normalize(input.GetText()))
+			if len(text) == 0 {
+				return
+			}
+			kana := string(text[0])
+			kanaStrokes, ok := goju.GetStrokes(kana)
+			if !ok {
+				drawing.SetText(fmt.Sprintf("No stroke data for %s", kana))
+				return
+			}
+
+			// Starting a new animation hides the frames of the previous one
+			animation++
+			current := animation
+			go func() {
+				canvas, _ := strokes.NewCanvas(strokes.Braille, 40)
+				stepper := goju.NewStrokeStepper(kanaStrokes)
+				for stepper.Next() {
+					frame := fmt.Sprintf("%s  stroke %d/%d\n\n%s", kana, stepper.Stroke(), stepper.Total(), strokes.Render(canvas, stepper.Drawn()))
+					t.app.QueueUpdateDraw(func() {
+						if current == animation {
+							drawing.SetText(frame)
+						}
+					})
+					time.Sleep(80 * time.Millisecond)
+				}
+			}()
+		}
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(drawing, 0, 1, false).
+		AddItem(input, 1, 0, true)
+
+	t.pages.AddPage("strokes", layout, true, false)
+	t.pages.SwitchToPage("strokes")
+}
+
 // showPracticeMenu shows the practice mode menu
 func (t *TUI) showPracticeMenu() {
 	menu := tview.NewFlex().SetDirection(tview.FlexRow)
@@ -148,7 +262,13 @@ func (t *TUI) showPracticeMenu() {
 	// Add practice options
 	options := tview.NewList().
 		AddItem("Start Practice", "Begin a new practice session", 's', func() {
-			t.startPractice()
+			t.startPractice(false, t.config.Practice.Choices)
+		}).
+		AddItem("Multiple choice", "Pick the romaji from options, look-alike kana among them", 'm', func() {
+			t.startPractice(false, max(t.config.Practice.Choices, defaultChoices))
+		}).
+		AddItem("Word reading", "Read example words made of the kana you practise", 'w', func() {
+			t.startPractice(true, t.config.Practice.Choices)
 		}).
 		AddItem("Back", "Return to main menu", 'b', func() {
 			t.pages.SwitchToPage("main")
@@ -159,9 +279,39 @@ func (t *TUI) showPracticeMenu() {
 	t.pages.SwitchToPage("practice")
 }
 
-// startPractice starts a new practice session
-func (t *TUI) startPractice() {
+// defaultChoices is the number of options in multiple-choice practice when
+// practice.choices does not ask for more
+const defaultChoices = 4
+
+// startPractice starts a new practice session, reading whole words if words
+// is set and offering that many romaji options when choices is above 1
+func (t *TUI) startPractice(words bool, choices int) {
+	system, err := t.config.RomanizationSystem()
+	if err != nil {
+		t.showError(err, "practice")
+		return
+	}
+	rows, err := t.config.PracticeRows()
+	if err != nil {
+		t.showError(err, "practice")
+		return
+	}
+	if _, ok := goju.GetDeck(t.config.Practice.Deck); t.config.Practice.Deck != "" && !ok {
+		t.showError(fmt.Errorf("unknown deck %q", t.config.Practice.Deck), "practice")
+		return
+	}
+
 	session := practise.NewPracticeSession(t.config.Practice.DefaultCount, t.config.Practice.Categories)
+	session.System = system
+	session.Language = t.config.Language
+	session.Rows = rows
+	session.Deck = t.config.Practice.Deck
+	session.Words = words
+	session.Choices = choices
+	if err := session.Validate(); err != nil {
+		t.showError(err, "practice")
+		return
+	}
 	question := tview.NewTextView().SetText("")
 	input := tview.NewInputField().SetLabel("Answer: ")
 
@@ -178,13 +328,15 @@ func (t *TUI) startPractice() {
 
 // setupPracticeSession sets up the practice session UI
 func (t *TUI) setupPracticeSession(session *practise.PracticeSession, question *tview.TextView, input *tview.InputField) {
-	// Get first character
-	char := session.GetNextCharacter()
-	session.Current.Character = char
-	session.Current.StartTime = time.Now()
+	// Get the next character or word
+	session.NextQuestion()
 
-	// Update question display
-	question.SetText(fmt.Sprintf("What is the romaji for: %s", char.Hiragana))
+	// Update question display, numbering the options to answer with
+	text := fmt.Sprintf("What is the romaji for: %s", session.Prompt())
+	for n, option := range session.Current.Options {
+		text += fmt.Sprintf("\n  %d) %s", n+1, option)
+	}
+	question.SetText(text)
 
 	// Handle input
 	input.SetDoneFunc(func(key tcell.Key) {
@@ -195,7 +347,14 @@ func (t *TUI) setupPracticeSession(session *practise.PracticeSession, question *
 			if !correct {
 				session.RecordMistake(answer)
 				// Show correct answer
-				question.SetText(fmt.Sprintf("Incorrect! The answer is: %s", char.Romaji))
+				feedback := fmt.Sprintf("Incorrect! The answer is: %s", session.Answer())
+				if confusable, ok := session.Confusion(answer); ok {
+					feedback += fmt.Sprintf("\n%s is %s, which is easily confused with %s", confusable.Kana(), confusable.Character.RomajiIn(session.System), session.Prompt())
+				}
+				if hint := session.Hint(); hint != "" {
+					feedback += fmt.Sprintf("\nRemember: %s", hint)
+				}
+				question.SetText(feedback)
 				// Wait for any key press
 				t.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 					if event.Key() == tcell.KeyRune {
@@ -213,6 +372,19 @@ func (t *TUI) setupPracticeSession(session *practise.PracticeSession, question *
 	})
 }
 
+// showError reports a problem, such as a bad configuration value, and
+// returns to the back page once dismissed
+func (t *TUI) showError(err error, back string) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Error: %v", err)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			t.pages.RemovePage("error")
+			t.pages.SwitchToPage(back)
+		})
+	t.pages.AddPage("error", modal, true, true)
+}
+
 // showHistory shows the practice history
 func (t *TUI) showHistory() {
 	// TODO: Implement history view
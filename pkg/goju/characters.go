@@ -2,22 +2,43 @@ package goju
 
 // Character represents a single Japanese character with its various representations
 type Character struct {
-	Hiragana string
-	Katakana string
-	Romaji   string
-	Category Category
+	ID             string // stable identifier, see CharacterID
+	Order          int    // position in the registry, starting at 1: categories in turn, table order within each
+	Hiragana       string
+	Katakana       string
+	Romaji         string // Hepburn
+	IPA            string // broad IPA of the character read on its own
+	Kunrei         string
+	Nihon          string
+	Category       Category
+	Row            Row      // gojūon row, empty outside the grid
+	Column         Column   // gojūon column, empty outside the grid
+	HiraganaOrigin string   // man'yōgana the hiragana developed from
+	KatakanaOrigin string   // man'yōgana the katakana developed from
+	Base           string   // ID of the plain form of a voiced kana, such as seion-ha for ば
+	Voiced         string   // ID of the dakuten form, such as dakuon-ba for は
+	SemiVoiced     string   // ID of the handakuten form, such as handaku-pa for は
+	Description    string   // what the character does, for modifiers and marks
+	Tags           []string // labels from custom data files
+	Notes          string   // free-form notes from custom data files
 }
 
 // Category represents the type of character
 type Category string
 
 const (
-	Seion   Category = "seion"   // 清音
-	Dakuon  Category = "dakuon"  // 浊音
-	Handaku Category = "handaku" // 半浊音
-	Yoon    Category = "yoon"    // 拗音
+	Seion    Category = "seion"    // 清音
+	Dakuon   Category = "dakuon"   // 浊音
+	Handaku  Category = "handaku"  // 半浊音
+	Yoon     Category = "yoon"     // 拗音
+	Gairaigo Category = "gairaigo" // 外来语音
+	Modifier Category = "modifier" // 小写假名与符号
 )
 
+// categoryOrder is the order in which categories are searched when several
+// entries share the same romaji
+var categoryOrder = []Category{Seion, Dakuon, Handaku, Yoon, Gairaigo, Modifier}
+
 // Characters contains all Japanese characters organized by category
 var Characters = map[Category][]Character{
 	Seion: {
@@ -132,40 +153,92 @@ var Characters = map[Category][]Character{
 		{Hiragana: "ぴゅ", Katakana: "ピュ", Romaji: "pyu"},
 		{Hiragana: "ぴょ", Katakana: "ピョ", Romaji: "pyo"},
 	},
+	Gairaigo: {
+		{Hiragana: "ふぁ", Katakana: "ファ", Romaji: "fa"},
+		{Hiragana: "ふぃ", Katakana: "フィ", Romaji: "fi"},
+		{Hiragana: "ふぇ", Katakana: "フェ", Romaji: "fe"},
+		{Hiragana: "ふぉ", Katakana: "フォ", Romaji: "fo"},
+		{Hiragana: "ふゅ", Katakana: "フュ", Romaji: "fyu"},
+		{Hiragana: "てぃ", Katakana: "ティ", Romaji: "ti"},
+		{Hiragana: "とぅ", Katakana: "トゥ", Romaji: "tu"},
+		{Hiragana: "てゅ", Katakana: "テュ", Romaji: "tyu"},
+		{Hiragana: "でぃ", Katakana: "ディ", Romaji: "di"},
+		{Hiragana: "どぅ", Katakana: "ドゥ", Romaji: "du"},
+		{Hiragana: "でゅ", Katakana: "デュ", Romaji: "dyu"},
+		{Hiragana: "うぃ", Katakana: "ウィ", Romaji: "wi"},
+		{Hiragana: "うぇ", Katakana: "ウェ", Romaji: "we"},
+		{Hiragana: "うぉ", Katakana: "ウォ", Romaji: "wo"},
+		{Hiragana: "いぇ", Katakana: "イェ", Romaji: "ye"},
+		{Hiragana: "ゔぁ", Katakana: "ヴァ", Romaji: "va"},
+		{Hiragana: "ゔぃ", Katakana: "ヴィ", Romaji: "vi"},
+		{Hiragana: "ゔ", Katakana: "ヴ", Romaji: "vu"},
+		{Hiragana: "ゔぇ", Katakana: "ヴェ", Romaji: "ve"},
+		{Hiragana: "ゔぉ", Katakana: "ヴォ", Romaji: "vo"},
+		{Hiragana: "しぇ", Katakana: "シェ", Romaji: "she"},
+		{Hiragana: "じぇ", Katakana: "ジェ", Romaji: "je"},
+		{Hiragana: "ちぇ", Katakana: "チェ", Romaji: "che"},
+		{Hiragana: "つぁ", Katakana: "ツァ", Romaji: "tsa"},
+		{Hiragana: "つぃ", Katakana: "ツィ", Romaji: "tsi"},
+		{Hiragana: "つぇ", Katakana: "ツェ", Romaji: "tse"},
+		{Hiragana: "つぉ", Katakana: "ツォ", Romaji: "tso"},
+		{Hiragana: "くぁ", Katakana: "クァ", Romaji: "kwa"},
+		{Hiragana: "ぐぁ", Katakana: "グァ", Romaji: "gwa"},
+	},
+	Modifier: {
+		{Hiragana: "ぁ", Katakana: "ァ", Romaji: "xa", Description: "Small a; follows a kana to form sounds such as ふぁ (fa)"},
+		{Hiragana: "ぃ", Katakana: "ィ", Romaji: "xi", Description: "Small i; follows a kana to form sounds such as てぃ (ti) and うぃ (wi)"},
+		{Hiragana: "ぅ", Katakana: "ゥ", Romaji: "xu", Description: "Small u; follows a kana to form sounds such as とぅ (tu)"},
+		{Hiragana: "ぇ", Katakana: "ェ", Romaji: "xe", Description: "Small e; follows a kana to form sounds such as しぇ (she) and ちぇ (che)"},
+		{Hiragana: "ぉ", Katakana: "ォ", Romaji: "xo", Description: "Small o; follows a kana to form sounds such as ふぉ (fo) and うぉ (wo)"},
+		{Hiragana: "っ", Katakana: "ッ", Romaji: "xtsu", Description: "Sokuon; doubles the consonant of the following kana, as in がっこう (gakkou)"},
+		{Hiragana: "ゃ", Katakana: "ャ", Romaji: "xya", Description: "Small ya; follows an i-column kana to form yoon such as きゃ (kya)"},
+		{Hiragana: "ゅ", Katakana: "ュ", Romaji: "xyu", Description: "Small yu; follows an i-column kana to form yoon such as きゅ (kyu)"},
+		{Hiragana: "ょ", Katakana: "ョ", Romaji: "xyo", Description: "Small yo; follows an i-column kana to form yoon such as きょ (kyo)"},
+		{Hiragana: "ゎ", Katakana: "ヮ", Romaji: "xwa", Description: "Small wa; follows く or ぐ in older spellings such as くゎ (kwa)"},
+		{ID: "modifier-choonpu", Hiragana: "ー", Katakana: "ー", Romaji: "-", Description: "Chōonpu; lengthens the vowel of the preceding kana, as in ラーメン (raamen)"},
+		{ID: "modifier-iteration", Hiragana: "ゝ", Katakana: "ヽ", Description: "Iteration mark; repeats the preceding kana, as in こゝろ (kokoro)"},
+		{ID: "modifier-voiced-iteration", Hiragana: "ゞ", Katakana: "ヾ", Description: "Voiced iteration mark; repeats the preceding kana with dakuten, as in みすゞ (misuzu)"},
+	},
 }
 
 // GetCharacterByHiragana returns a character by its hiragana representation
 func GetCharacterByHiragana(hiragana string) (Character, bool) {
-	for _, chars := range Characters {
-		for _, char := range chars {
-			if char.Hiragana == hiragana {
-				return char, true
-			}
-		}
-	}
-	return Character{}, false
+	return defaultRegistry.ByHiragana(hiragana)
 }
 
 // GetCharacterByKatakana returns a character by its katakana representation
 func GetCharacterByKatakana(katakana string) (Character, bool) {
-	for _, chars := range Characters {
-		for _, char := range chars {
-			if char.Katakana == katakana {
-				return char, true
-			}
-		}
-	}
-	return Character{}, false
+	return defaultRegistry.ByKatakana(katakana)
 }
 
-// GetCharacterByRomaji returns a character by its romaji representation
+// GetCharacterByRomaji returns a character by its Hepburn romaji.
+// When several characters share the spelling the most frequent is returned.
 func GetCharacterByRomaji(romaji string) (Character, bool) {
-	for _, chars := range Characters {
-		for _, char := range chars {
-			if char.Romaji == romaji {
-				return char, true
-			}
-		}
-	}
-	return Character{}, false
+	return GetCharacterByRomajiIn(romaji, Hepburn)
+}
+
+// GetCharacterByID returns a character by its stable ID
+func GetCharacterByID(id string) (Character, bool) {
+	return defaultRegistry.ByID(id)
+}
+
+// GetCharacterByCodepoint returns a single-codepoint character by its
+// hiragana or katakana rune
+func GetCharacterByCodepoint(cp rune) (Character, bool) {
+	return defaultRegistry.ByCodepoint(cp)
+}
+
+// GetCategories returns every known category, built-in ones first
+func GetCategories() []Category {
+	return defaultRegistry.Categories()
+}
+
+// GetDeck returns the characters of a named deck from the custom data files
+func GetDeck(name string) ([]Character, bool) {
+	return defaultRegistry.Deck(name)
+}
+
+// GetCharactersByCategory returns the characters in a category in gojūon order
+func GetCharactersByCategory(category Category) []Character {
+	return defaultRegistry.ByCategory(category)
 }
@@ -0,0 +1,223 @@
+package goju
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// gojuonOrder lists the plain kana in dictionary order, five to a row so
+// that a kana's column gives its vowel. Gaps in the や and わ rows are
+// marked with a dot.
+const gojuonOrder = "あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもや・ゆ・よらりるれろわゐ・ゑをん"
+
+// gojuonRank maps each plain kana to its position in gojuonOrder
+var gojuonRank = func() map[rune]int {
+	rank := make(map[rune]int)
+	for i, r := range []rune(gojuonOrder) {
+		if r != '・' {
+			rank[r] = i
+		}
+	}
+	return rank
+}()
+
+// smallToLarge maps each small kana to the full-size kana it is written like
+var smallToLarge = map[rune]rune{
+	'ぁ': 'あ', 'ぃ': 'い', 'ぅ': 'う', 'ぇ': 'え', 'ぉ': 'お',
+	'っ': 'つ', 'ゃ': 'や', 'ゅ': 'ゆ', 'ょ': 'よ', 'ゎ': 'わ',
+	'ゕ': 'か', 'ゖ': 'け',
+}
+
+// LongVowelRule is how a Collator sorts the long vowel mark ー
+type LongVowelRule int
+
+const (
+	// LongVowelAsVowel sorts ー as the vowel it lengthens, so カード sorts
+	// as かあど, just before カアド
+	LongVowelAsVowel LongVowelRule = iota
+	// LongVowelIgnored skips ー, so カード sorts as かど
+	LongVowelIgnored
+	// LongVowelLast sorts ー as a letter of its own after ん
+	LongVowelLast
+)
+
+// Collator compares kana strings in gojūon order. The zero value follows
+// Japanese dictionary order: kana that differ only in dakuten, size or
+// script sort together, with plain before voiced before half-voiced, small
+// before full-size and hiragana before katakana breaking ties. Text that
+// is not kana sorts after all kana by code point.
+type Collator struct {
+	DistinctVoicing bool          // sort が as a letter of its own between か and き
+	DistinctSmall   bool          // sort ゃ as a letter of its own just before や
+	DistinctScripts bool          // sort katakana after all hiragana
+	LongVowel       LongVowelRule // how to sort ー
+}
+
+// Ranks beyond the gojūon table, spaced out so they never meet it
+const (
+	collateLongMark = 100
+	collateOther    = 200
+	collateKatakana = 1 << 30
+)
+
+// Kinds of kana, used to break ties after voicing
+const (
+	collateSmall = iota
+	collateLong
+	collatePlain
+)
+
+// collationKey holds the sort weights of a string, compared level by level
+type collationKey struct {
+	primary   []int // gojūon position
+	secondary []int // voicing and size
+	tertiary  []int // script
+}
+
+// key works out the sort weights of s
+func (c Collator) key(s string) collationKey {
+	var k collationKey
+	prev, prevVoicing := -1, 0
+	add := func(rank, voicing, kind, script int) {
+		primary := rank * 16
+		if c.DistinctVoicing {
+			primary += voicing * 4
+		}
+		if c.DistinctSmall && kind != collateSmall {
+			primary += 2
+		}
+		if c.DistinctScripts {
+			primary += script * collateKatakana
+		}
+		k.primary = append(k.primary, primary)
+		k.secondary = append(k.secondary, voicing*4+kind)
+		k.tertiary = append(k.tertiary, script)
+		if rank < collateLongMark {
+			prev, prevVoicing = rank, voicing
+		}
+	}
+
+	for _, r := range Normalize(s) {
+		script := 0
+		if hiragana := []rune(ToHiragana(string(r))); len(hiragana) == 1 && hiragana[0] != r {
+			r, script = hiragana[0], 1
+		}
+
+		switch {
+		case string(r) == choonpu:
+			switch {
+			case c.LongVowel == LongVowelIgnored:
+			case c.LongVowel == LongVowelAsVowel && prev >= 0 && prev < gojuonRank['ん']:
+				add(prev%5, 0, collateLong, script)
+			default:
+				add(collateLongMark, 0, collatePlain, script)
+			}
+			continue
+		case r == 'ゝ' || r == 'ゞ':
+			if prev >= 0 {
+				voicing := prevVoicing
+				if r == 'ゞ' {
+					voicing = 1
+				}
+				add(prev, voicing, collatePlain, script)
+				continue
+			}
+		}
+
+		kind := collatePlain
+		if large, ok := smallToLarge[r]; ok {
+			r, kind = large, collateSmall
+		}
+		voicing := 0
+		if plain := []rune(Devoice(string(r)))[0]; plain != r {
+			voicing = 1
+			if semiVoicedComposition[plain] == r {
+				voicing = 2
+			}
+			r = plain
+		}
+		if rank, ok := gojuonRank[r]; ok {
+			add(rank, voicing, kind, script)
+			continue
+		}
+		add(collateOther+int(r), 0, collatePlain, script)
+	}
+	return k
+}
+
+// Compare returns -1 if a sorts before b, 1 if after and 0 if they are the
+// same string
+func (c Collator) Compare(a, b string) int {
+	return compareCollated(collated{text: a, key: c.key(a)}, collated{text: b, key: c.key(b)})
+}
+
+// Less reports whether a sorts before b
+func (c Collator) Less(a, b string) bool {
+	return c.Compare(a, b) < 0
+}
+
+// Sort sorts kana strings in place
+func (c Collator) Sort(s []string) {
+	sortCollated(c, s, func(text string) string { return text })
+}
+
+// SortCharacters sorts characters in place by their hiragana, or their
+// katakana when they have no hiragana
+func (c Collator) SortCharacters(chars []Character) {
+	sortCollated(c, chars, func(char Character) string {
+		if char.Hiragana != "" {
+			return char.Hiragana
+		}
+		return char.Katakana
+	})
+}
+
+// SortWords sorts words in place by their kana
+func (c Collator) SortWords(words []Word) {
+	sortCollated(c, words, func(word Word) string { return word.Kana })
+}
+
+// collated is a string with its sort weights and where it came from
+type collated struct {
+	text  string
+	key   collationKey
+	index int
+}
+
+// sortCollated sorts items by their text, working out each key once
+func sortCollated[T any](c Collator, items []T, text func(T) string) {
+	keys := make([]collated, len(items))
+	for i, item := range items {
+		keys[i] = collated{text: text(item), index: i}
+		keys[i].key = c.key(keys[i].text)
+	}
+	sort.SliceStable(keys, func(a, b int) bool {
+		return compareCollated(keys[a], keys[b]) < 0
+	})
+	sorted := make([]T, len(items))
+	for i, k := range keys {
+		sorted[i] = items[k.index]
+	}
+	copy(items, sorted)
+}
+
+// compareCollated compares two strings level by level, falling back to
+// their code points so the order is total
+func compareCollated(a, b collated) int {
+	if n := slices.Compare(a.key.primary, b.key.primary); n != 0 {
+		return n
+	}
+	if n := slices.Compare(a.key.secondary, b.key.secondary); n != 0 {
+		return n
+	}
+	if n := slices.Compare(a.key.tertiary, b.key.tertiary); n != 0 {
+		return n
+	}
+	return strings.Compare(a.text, b.text)
+}
+
+// SortKana sorts kana strings in place in dictionary order
+func SortKana(s []string) {
+	Collator{}.Sort(s)
+}
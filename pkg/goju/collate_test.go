@@ -0,0 +1,141 @@
+package goju
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollatorSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		collator Collator
+		input    []string
+		want     []string
+	}{
+		{
+			name:  "gojūon order",
+			input: []string{"ん", "を", "さ", "か", "あ", "わ", "や", "ゐ"},
+			want:  []string{"あ", "か", "さ", "や", "わ", "ゐ", "を", "ん"},
+		},
+		{
+			name:  "dakuten break ties",
+			input: []string{"はは", "ぱぱ", "はば", "ばば", "はひ"},
+			want:  []string{"はは", "はば", "ばば", "ぱぱ", "はひ"},
+		},
+		{
+			name:     "distinct voicing",
+			collator: Collator{DistinctVoicing: true},
+			input:    []string{"がき", "かこ", "きか"},
+			want:     []string{"かこ", "がき", "きか"},
+		},
+		{
+			name:  "small kana break ties",
+			input: []string{"きよう", "きょう", "きようか"},
+			want:  []string{"きょう", "きよう", "きようか"},
+		},
+		{
+			name:     "distinct small kana",
+			collator: Collator{DistinctSmall: true},
+			input:    []string{"きよう", "きょうか", "きょう"},
+			want:     []string{"きょう", "きょうか", "きよう"},
+		},
+		{
+			name:  "long vowel as vowel",
+			input: []string{"カカ", "カアド", "カード", "カド"},
+			want:  []string{"カード", "カアド", "カカ", "カド"},
+		},
+		{
+			name:     "long vowel ignored",
+			collator: Collator{LongVowel: LongVowelIgnored},
+			input:    []string{"カカ", "カード", "カアド"},
+			want:     []string{"カアド", "カカ", "カード"},
+		},
+		{
+			name:     "long vowel last",
+			collator: Collator{LongVowel: LongVowelLast},
+			input:    []string{"カード", "カン", "カア"},
+			want:     []string{"カア", "カン", "カード"},
+		},
+		{
+			name:  "scripts sort together",
+			input: []string{"ネコ", "いぬ", "ねこ", "イヌ"},
+			want:  []string{"いぬ", "イヌ", "ねこ", "ネコ"},
+		},
+		{
+			name:     "distinct scripts",
+			collator: Collator{DistinctScripts: true},
+			input:    []string{"ネコ", "いぬ", "ねこ", "イヌ"},
+			want:     []string{"いぬ", "ねこ", "イヌ", "ネコ"},
+		},
+		{
+			name:  "iteration marks repeat the kana before",
+			input: []string{"こころ", "こゝろ", "こごろ", "こゞろ", "こさ"},
+			want:  []string{"こころ", "こゝろ", "こごろ", "こゞろ", "こさ"},
+		},
+		{
+			name:  "other text after kana",
+			input: []string{"abc", "ん", "あ"},
+			want:  []string{"あ", "ん", "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := append([]string(nil), tt.input...)
+			tt.collator.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sort(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollatorCompare(t *testing.T) {
+	var c Collator
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"か", "か", 0},
+		{"か", "が", -1},
+		{"ぱ", "ば", 1},
+		{"ｶﾞ", "が", 1}, // half-width katakana is still katakana
+		{"ヴ", "う", 1},
+		{"つ", "っ", 1},
+		{"", "あ", -1},
+	}
+
+	for _, tt := range tests {
+		if got := c.Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := c.Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestSortCharactersAndWords(t *testing.T) {
+	var chars []Character
+	for _, kana := range []string{"ン", "ぎゃ", "か", "きゃ", "が"} {
+		char, ok := DefaultRegistry().ByKana(kana)
+		if !ok {
+			t.Fatalf("no character %s", kana)
+		}
+		chars = append(chars, char)
+	}
+	Collator{}.SortCharacters(chars)
+	var got []string
+	for _, char := range chars {
+		got = append(got, char.Hiragana)
+	}
+	if want := []string{"か", "が", "きゃ", "ぎゃ", "ん"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortCharacters() = %v, want %v", got, want)
+	}
+
+	words := []Word{{Kana: "ねこ"}, {Kana: "いぬ"}, {Kana: "さかな"}}
+	Collator{}.SortWords(words)
+	if words[0].Kana != "いぬ" || words[1].Kana != "さかな" || words[2].Kana != "ねこ" {
+		t.Errorf("SortWords() = %v", words)
+	}
+}
@@ -0,0 +1,195 @@
+package goju
+
+import (
+	"sort"
+)
+
+// Confusable is a character that looks like another one in a script
+type Confusable struct {
+	Character Character
+	Script    Script  // script in which the two look alike
+	Weight    float64 // how often learners mix them up, from 0 to 1
+}
+
+// confusablePair links two kana of the same script that look alike
+type confusablePair struct {
+	a, b   string
+	weight float64
+}
+
+// confusablePairs is the curated confusability graph. Weights reflect how
+// often learners mix up the pair: 1 for near-identical shapes, around 0.5
+// for a shared stroke pattern and lower for a passing resemblance.
+var confusablePairs = []confusablePair{
+	// Hiragana
+	{"ぬ", "め", 1},
+	{"わ", "ね", 0.9},
+	{"わ", "れ", 0.9},
+	{"ね", "れ", 0.9},
+	{"る", "ろ", 0.9},
+	{"は", "ほ", 0.8},
+	{"さ", "ち", 0.8},
+	{"あ", "お", 0.8},
+	{"さ", "き", 0.7},
+	{"い", "り", 0.7},
+	{"た", "な", 0.6},
+	{"ち", "ら", 0.6},
+	{"ぬ", "ね", 0.5},
+	{"あ", "め", 0.5},
+	{"け", "は", 0.5},
+	{"す", "む", 0.5},
+	{"こ", "い", 0.4},
+	{"ほ", "ま", 0.4},
+	{"ま", "よ", 0.4},
+	{"の", "め", 0.4},
+	{"お", "む", 0.4},
+	{"け", "に", 0.4},
+	{"う", "ら", 0.3},
+	{"う", "つ", 0.3},
+	{"く", "へ", 0.3},
+	{"ば", "ぱ", 0.6},
+	{"び", "ぴ", 0.6},
+	{"ぶ", "ぷ", 0.6},
+	{"べ", "ぺ", 0.6},
+	{"ぼ", "ぽ", 0.6},
+
+	// Katakana
+	{"シ", "ツ", 1},
+	{"ソ", "ン", 1},
+	{"ク", "ケ", 0.7},
+	{"ヌ", "ス", 0.7},
+	{"ク", "タ", 0.6},
+	{"ウ", "ワ", 0.6},
+	{"ヌ", "メ", 0.6},
+	{"コ", "ユ", 0.6},
+	{"チ", "テ", 0.6},
+	{"マ", "ム", 0.6},
+	{"ア", "マ", 0.6},
+	{"フ", "ワ", 0.5},
+	{"コ", "ロ", 0.5},
+	{"ノ", "ソ", 0.5},
+	{"ノ", "ン", 0.5},
+	{"ワ", "ヲ", 0.5},
+	{"ラ", "ヲ", 0.5},
+	{"ヤ", "セ", 0.5},
+	{"ル", "レ", 0.5},
+	{"キ", "チ", 0.5},
+	{"ナ", "メ", 0.4},
+	{"ウ", "フ", 0.4},
+	{"エ", "ユ", 0.4},
+	{"ネ", "ホ", 0.4},
+	{"ソ", "リ", 0.4},
+	{"ハ", "ヘ", 0.3},
+	{"バ", "パ", 0.6},
+	{"ビ", "ピ", 0.6},
+	{"ブ", "プ", 0.6},
+	{"ベ", "ペ", 0.6},
+	{"ボ", "ポ", 0.6},
+}
+
+// similarEdge is one side of a confusable pair in the registry's graph
+type similarEdge struct {
+	index  int
+	script Script
+	weight float64
+}
+
+// indexConfusables builds the confusability graph from confusablePairs.
+// Pairs naming kana the registry does not know are skipped.
+func (r *Registry) indexConfusables() {
+	r.similar = make(map[int][]similarEdge)
+	for _, pair := range confusablePairs {
+		a, script, ok := r.kanaIndex(pair.a)
+		if !ok {
+			continue
+		}
+		b, _, ok := r.kanaIndex(pair.b)
+		if !ok {
+			continue
+		}
+		r.addSimilar(a, similarEdge{b, script, pair.weight})
+		r.addSimilar(b, similarEdge{a, script, pair.weight})
+	}
+	for _, edges := range r.similar {
+		sort.SliceStable(edges, func(i, j int) bool {
+			return edges[i].weight > edges[j].weight
+		})
+	}
+}
+
+// addSimilar links a character to a look-alike in one script. Characters
+// that look alike in both scripts, such as ば and ぱ, get one link per
+// script, and a repeated pair keeps the stronger weight.
+func (r *Registry) addSimilar(index int, edge similarEdge) {
+	for i, known := range r.similar[index] {
+		if known.index == edge.index && known.script == edge.script {
+			if edge.weight > known.weight {
+				r.similar[index][i] = edge
+			}
+			return
+		}
+	}
+	r.similar[index] = append(r.similar[index], edge)
+}
+
+// kanaIndex finds the position of a kana and the script it is written in
+func (r *Registry) kanaIndex(kana string) (int, Script, bool) {
+	if index, ok := r.byHiragana[kana]; ok {
+		return index, ScriptHiragana, true
+	}
+	if index, ok := r.byKatakana[kana]; ok {
+		return index, ScriptKatakana, true
+	}
+	return 0, "", false
+}
+
+// SimilarTo returns the characters that look like char in either script,
+// most confusable first. A look-alike in both scripts, such as ぱ for ば,
+// is listed once for each.
+func (r *Registry) SimilarTo(char Character) []Confusable {
+	index, ok := r.byID[char.ID]
+	if !ok {
+		return nil
+	}
+	edges := r.similar[index]
+	if len(edges) == 0 {
+		return nil
+	}
+	similar := make([]Confusable, len(edges))
+	for i, edge := range edges {
+		similar[i] = Confusable{
+			Character: r.chars[edge.index],
+			Script:    edge.script,
+			Weight:    edge.weight,
+		}
+	}
+	return similar
+}
+
+// SimilarIn returns the characters that look like char when both are
+// written in script, most confusable first
+func (r *Registry) SimilarIn(char Character, script Script) []Confusable {
+	var similar []Confusable
+	for _, confusable := range r.SimilarTo(char) {
+		if confusable.Script == script {
+			similar = append(similar, confusable)
+		}
+	}
+	return similar
+}
+
+// SimilarTo returns the characters commonly confused with char
+func SimilarTo(char Character) []Confusable {
+	return defaultRegistry.SimilarTo(char)
+}
+
+// SimilarIn returns the characters commonly confused with char in script
+func SimilarIn(char Character, script Script) []Confusable {
+	return defaultRegistry.SimilarIn(char, script)
+}
+
+// Kana returns the confusable character written in the script it is
+// confused in
+func (c Confusable) Kana() string {
+	return kana(c.Character, c.Script)
+}
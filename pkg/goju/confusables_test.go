@@ -0,0 +1,96 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestSimilarTo(t *testing.T) {
+	tests := []struct {
+		kana string
+		want []string // look-alikes in the script they are confused in
+	}{
+		{"シ", []string{"ツ"}},
+		{"ソ", []string{"ン", "ノ", "リ"}},
+		{"ぬ", []string{"め", "ス", "メ", "ね"}},
+		{"わ", []string{"ね", "れ", "ウ", "フ", "ヲ"}},
+		{"ば", []string{"ぱ", "パ"}},
+		{"きゃ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			char, _ := DefaultRegistry().ByKana(tt.kana)
+			similar := SimilarTo(char)
+			if len(similar) != len(tt.want) {
+				t.Fatalf("SimilarTo(%s) = %v, want %v", tt.kana, similar, tt.want)
+			}
+			for i, confusable := range similar {
+				if confusable.Kana() != tt.want[i] {
+					t.Errorf("SimilarTo(%s)[%d] = %v, want %v", tt.kana, i, confusable.Kana(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSimilarIn(t *testing.T) {
+	tests := []struct {
+		kana   string
+		script Script
+		want   []string
+	}{
+		{"バ", ScriptKatakana, []string{"パ"}},
+		{"バ", ScriptHiragana, []string{"ぱ"}},
+		{"シ", ScriptKatakana, []string{"ツ"}},
+		{"し", ScriptHiragana, nil},
+		{"わ", ScriptKatakana, []string{"ウ", "フ", "ヲ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana+" "+string(tt.script), func(t *testing.T) {
+			char, _ := DefaultRegistry().ByKana(tt.kana)
+			similar := SimilarIn(char, tt.script)
+			if len(similar) != len(tt.want) {
+				t.Fatalf("SimilarIn(%s, %s) = %v, want %v", tt.kana, tt.script, similar, tt.want)
+			}
+			for i, confusable := range similar {
+				if confusable.Kana() != tt.want[i] {
+					t.Errorf("SimilarIn(%s, %s)[%d] = %v, want %v", tt.kana, tt.script, i, confusable.Kana(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConfusablesAreSymmetric(t *testing.T) {
+	for _, char := range DefaultRegistry().All() {
+		for _, confusable := range SimilarTo(char) {
+			found := false
+			for _, back := range SimilarTo(confusable.Character) {
+				if back.Character.ID == char.ID && back.Weight == confusable.Weight {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s looks like %s but not the other way round", char.ID, confusable.Character.ID)
+			}
+		}
+	}
+}
+
+func TestConfusablePairsAreKnown(t *testing.T) {
+	for _, pair := range confusablePairs {
+		a, scriptA, okA := DefaultRegistry().kanaIndex(pair.a)
+		b, scriptB, okB := DefaultRegistry().kanaIndex(pair.b)
+		if !okA || !okB {
+			t.Errorf("pair %s/%s names an unknown kana", pair.a, pair.b)
+			continue
+		}
+		if scriptA != scriptB || a == b {
+			t.Errorf("pair %s/%s mixes scripts or links a kana to itself", pair.a, pair.b)
+		}
+		if pair.weight <= 0 || pair.weight > 1 {
+			t.Errorf("pair %s/%s has weight %v outside (0, 1]", pair.a, pair.b, pair.weight)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package goju
+
+import (
+	"strings"
+)
+
+// kanaFrequency holds the approximate number of occurrences per 10,000 kana
+// in modern written Japanese, keyed by hiragana. It is used to rank
+// characters that share a romanization, so only relative order matters.
+var kanaFrequency = map[string]int{
+	"あ": 170, "い": 560, "う": 330, "え": 75, "お": 120,
+	"か": 350, "き": 170, "く": 190, "け": 110, "こ": 200,
+	"さ": 140, "し": 380, "す": 240, "せ": 80, "そ": 90,
+	"た": 330, "ち": 90, "つ": 130, "て": 310, "と": 330,
+	"な": 310, "に": 320, "ぬ": 5, "ね": 40, "の": 480,
+	"は": 260, "ひ": 45, "ふ": 35, "へ": 20, "ほ": 40,
+	"ま": 230, "み": 70, "む": 20, "め": 55, "も": 160,
+	"や": 55, "ゆ": 20, "よ": 80,
+	"ら": 110, "り": 170, "る": 270, "れ": 150, "ろ": 40,
+	"わ": 60, "を": 230, "ん": 380,
+	"が": 210, "ぎ": 15, "ぐ": 15, "げ": 15, "ご": 35,
+	"ざ": 10, "じ": 60, "ず": 30, "ぜ": 5, "ぞ": 5,
+	"だ": 150, "ぢ": 1, "づ": 5, "で": 200, "ど": 60,
+	"ば": 30, "び": 10, "ぶ": 15, "べ": 15, "ぼ": 5,
+	"ぱ": 5, "ぴ": 2, "ぷ": 2, "ぺ": 2, "ぽ": 2,
+	"きゃ": 3, "きゅ": 5, "きょ": 20,
+	"しゃ": 15, "しゅ": 20, "しょ": 30,
+	"ちゃ": 10, "ちゅ": 10, "ちょ": 10,
+	"にゃ": 1, "にゅ": 3, "にょ": 1,
+	"ひゃ": 1, "ひゅ": 1, "ひょ": 3,
+	"みゃ": 1, "みゅ": 1, "みょ": 2,
+	"りゃ": 2, "りゅ": 5, "りょ": 10,
+	"ぎゃ": 1, "ぎゅ": 2, "ぎょ": 5,
+	"じゃ": 8, "じゅ": 15, "じょ": 25,
+	"びゃ": 1, "びゅ": 1, "びょ": 3,
+	"ぴゃ": 1, "ぴゅ": 1, "ぴょ": 1,
+}
+
+// Frequency returns the approximate number of occurrences of the character
+// per 10,000 kana, or 0 if unknown
+func (c Character) Frequency() int {
+	return kanaFrequency[c.Hiragana]
+}
+
+// FindByRomaji returns every character whose romanization in any supported
+// system matches romaji, most frequent first
+func FindByRomaji(romaji string) []Character {
+	return defaultRegistry.ByAnyRomaji(romaji)
+}
+
+// FindByRomajiIn returns every character whose romanization in the given
+// system matches romaji, most frequent first
+func FindByRomajiIn(romaji string, system RomanizationSystem) []Character {
+	return defaultRegistry.ByRomaji(romaji, system)
+}
+
+// AcceptedRomaji returns the spellings that identify the character in the
+// given system. When the system's spelling is shared with another kana, as
+// ji is by じ and ぢ, the spellings the other systems use are accepted too.
+func AcceptedRomaji(char Character, system RomanizationSystem) []string {
+	spelling := char.RomajiIn(system)
+	accepted := []string{spelling}
+	if len(FindByRomajiIn(spelling, system)) < 2 {
+		return accepted
+	}
+
+	for _, other := range RomanizationSystems {
+		alt := char.RomajiIn(other)
+		duplicate := false
+		for _, seen := range accepted {
+			if seen == alt {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			accepted = append(accepted, alt)
+		}
+	}
+	return accepted
+}
+
+// AcceptsRomaji reports whether romaji spells the kana string, each kana
+// written with one of its AcceptedRomaji spellings, so はなぢ is accepted as
+// hanaji or hanadi. As in Transliterate, っ doubles the consonant after it
+// and ー repeats the vowel before it; ん may be written n or n'.
+func AcceptsRomaji(kana, romaji string, system RomanizationSystem) bool {
+	tokens := tokenize(kana)
+	var units [][]string // accepted spellings of each kana in turn
+	var previous *Character
+	for i, tok := range tokens {
+		switch tok.Kind {
+		case tokenKana:
+			char := tok.Char
+			switch char.ID {
+			case iterationMarkID, voicedIterationMarkID:
+				if previous == nil {
+					return false
+				}
+				char = *previous
+				if tok.Char.ID == voicedIterationMarkID {
+					voiced, ok := voicedForm(char)
+					if !ok {
+						return false
+					}
+					char = voiced
+				}
+			}
+			if char.Category != Modifier {
+				previous = &tokens[i].Char
+			}
+			spellings := AcceptedRomaji(char, system)
+			if spellings[0] == "n" {
+				spellings = append(spellings, "n'")
+			}
+			if i > 0 && tokens[i-1].Kind == tokenSokuon {
+				var doubled []string
+				for _, spelling := range spellings {
+					if consonant, ok := geminate(spelling); ok {
+						doubled = append(doubled, consonant+spelling)
+					}
+				}
+				spellings = doubled
+			}
+			if len(spellings) == 0 || spellings[0] == "" {
+				return false
+			}
+			units = append(units, spellings)
+		case tokenSokuon:
+			// Spelled with the kana after it
+			if i+1 == len(tokens) || tokens[i+1].Kind != tokenKana {
+				return false
+			}
+		case tokenChoonpu:
+			if len(units) == 0 {
+				return false
+			}
+			var long []string
+			for _, spelling := range units[len(units)-1] {
+				if vowel := spelling[len(spelling)-1]; isVowel(vowel) {
+					long = append(long, spelling+string(vowel))
+				}
+			}
+			if len(long) == 0 {
+				return false
+			}
+			units[len(units)-1] = long
+		default:
+			return false
+		}
+	}
+
+	// Walk every way the spellings can line up with romaji
+	ends := map[int]bool{0: true}
+	for _, spellings := range units {
+		next := make(map[int]bool)
+		for pos := range ends {
+			for _, spelling := range spellings {
+				if strings.HasPrefix(romaji[pos:], spelling) {
+					next[pos+len(spelling)] = true
+				}
+			}
+		}
+		ends = next
+	}
+	return len(units) > 0 && ends[len(romaji)]
+}
@@ -0,0 +1,106 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestFindByRomaji(t *testing.T) {
+	tests := []struct {
+		name     string
+		romaji   string
+		expected []string
+	}{
+		{"Ambiguous ji", "ji", []string{"じ", "ぢ"}},
+		{"Ambiguous zu", "zu", []string{"ず", "づ"}},
+		{"Kunrei zi", "zi", []string{"じ", "ぢ"}},
+		{"Kunrei o", "o", []string{"を", "お"}},
+		{"Shared with loanword", "di", []string{"ぢ", "でぃ"}},
+		{"Loanword after native tu", "tu", []string{"つ", "とぅ"}},
+		{"Loanword after native wo", "wo", []string{"を", "うぉ"}},
+		{"Loanword only", "fa", []string{"ふぁ"}},
+		{"Unique", "ka", []string{"か"}},
+		{"Not found", "xx", nil},
+		{"Empty string", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to catch any dependence on map iteration order
+			for run := 0; run < 10; run++ {
+				got := FindByRomaji(tt.romaji)
+				if len(got) != len(tt.expected) {
+					t.Fatalf("FindByRomaji() = %v, want %v", got, tt.expected)
+				}
+				for i, char := range got {
+					if char.Hiragana != tt.expected[i] {
+						t.Fatalf("FindByRomaji()[%d] = %v, want %v", i, char.Hiragana, tt.expected[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAcceptedRomaji(t *testing.T) {
+	tests := []struct {
+		name     string
+		hiragana string
+		system   RomanizationSystem
+		expected []string
+	}{
+		{"Unambiguous", "か", Hepburn, []string{"ka"}},
+		{"Hepburn ji for ji", "じ", Hepburn, []string{"ji", "zi"}},
+		{"Hepburn ji for di", "ぢ", Hepburn, []string{"ji", "zi", "di"}},
+		{"Hepburn zu for du", "づ", Hepburn, []string{"zu", "du"}},
+		{"Nihon du", "づ", Nihon, []string{"du", "zu"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, _ := GetCharacterByHiragana(tt.hiragana)
+			got := AcceptedRomaji(char, tt.system)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("AcceptedRomaji() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("AcceptedRomaji() = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestAcceptsRomaji(t *testing.T) {
+	tests := []struct {
+		kana   string
+		romaji string
+		system RomanizationSystem
+		want   bool
+	}{
+		{"ねこ", "neko", Hepburn, true},
+		{"はなぢ", "hanaji", Hepburn, true},
+		{"はなぢ", "hanadi", Hepburn, true},
+		{"みかづき", "mikaduki", Hepburn, true},
+		{"ちず", "tizu", Hepburn, false}, // chi alone is Hepburn's ち
+		{"ちず", "tizu", Kunrei, true},
+		{"きって", "kitte", Hepburn, true},
+		{"きって", "kite", Hepburn, false},
+		{"まっちゃ", "matcha", Hepburn, true},
+		{"らーめん", "raamen", Hepburn, true},
+		{"ほんや", "hon'ya", Hepburn, true},
+		{"ほんや", "honya", Hepburn, true},
+		{"こころ", "koko", Hepburn, false},
+		{"こゝろ", "kokoro", Hepburn, true},
+		{"ねこ猫", "neko", Hepburn, false},
+		{"", "", Hepburn, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana+" "+tt.romaji, func(t *testing.T) {
+			if got := AcceptsRomaji(tt.kana, tt.romaji, tt.system); got != tt.want {
+				t.Errorf("AcceptsRomaji(%q, %q, %s) = %v, want %v", tt.kana, tt.romaji, tt.system, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package goju
+
+import (
+	"strings"
+)
+
+// Row represents a consonant row of the gojūon table (行, gyō)
+type Row string
+
+// Column represents a vowel column of the gojūon table (段, dan)
+type Column string
+
+const (
+	RowA  Row = "a"
+	RowKa Row = "ka"
+	RowSa Row = "sa"
+	RowTa Row = "ta"
+	RowNa Row = "na"
+	RowHa Row = "ha"
+	RowMa Row = "ma"
+	RowYa Row = "ya"
+	RowRa Row = "ra"
+	RowWa Row = "wa"
+	RowN  Row = "n" // ん stands outside the grid
+	RowGa Row = "ga"
+	RowZa Row = "za"
+	RowDa Row = "da"
+	RowBa Row = "ba"
+	RowPa Row = "pa"
+)
+
+const (
+	ColumnA Column = "a"
+	ColumnI Column = "i"
+	ColumnU Column = "u"
+	ColumnE Column = "e"
+	ColumnO Column = "o"
+)
+
+// Columns lists the vowel columns in table order
+var Columns = []Column{ColumnA, ColumnI, ColumnU, ColumnE, ColumnO}
+
+// SeionRows lists the rows of the canonical gojūon table in order
+var SeionRows = []Row{RowA, RowKa, RowSa, RowTa, RowNa, RowHa, RowMa, RowYa, RowRa, RowWa}
+
+// VoicedRows lists the rows written with dakuten or handakuten
+var VoicedRows = []Row{RowGa, RowZa, RowDa, RowBa, RowPa}
+
+// gridCategories are the categories whose characters sit in the grid
+var gridCategories = map[Category]bool{Seion: true, Dakuon: true, Handaku: true}
+
+// setGridPosition fills in the row and column of a single kana from its
+// Nihon-shiki spelling, which writes every row with a single consonant
+// (ta, ti, tu, te, to). Yoon, loanword sounds and marks have no position.
+func setGridPosition(char *Character) {
+	if char.Row != "" || !gridCategories[char.Category] || char.Nihon == "" {
+		return
+	}
+	if char.Nihon == "n" {
+		char.Row = RowN
+		return
+	}
+
+	vowel := char.Nihon[len(char.Nihon)-1:]
+	if !isVowel(vowel[0]) {
+		return
+	}
+	char.Column = Column(vowel)
+	char.Row = Row(strings.TrimSuffix(char.Nihon, vowel) + "a")
+}
+
+// Grid lays out characters by row and column. Cells without a character,
+// such as yi, ye and wu, are nil.
+type Grid struct {
+	Rows    []Row
+	Columns []Column
+	Cells   [][]*Character
+}
+
+// NewGrid builds a grid with the given rows from the default registry
+func NewGrid(rows ...Row) Grid {
+	grid := Grid{
+		Rows:    rows,
+		Columns: Columns,
+		Cells:   make([][]*Character, len(rows)),
+	}
+	for i, row := range rows {
+		grid.Cells[i] = make([]*Character, len(Columns))
+		for _, char := range GetCharactersByRow(row) {
+			for j, column := range Columns {
+				if char.Column == column && grid.Cells[i][j] == nil {
+					char := char
+					grid.Cells[i][j] = &char
+				}
+			}
+		}
+	}
+	return grid
+}
+
+// GojuonGrid returns the canonical 10×5 gojūon table
+func GojuonGrid() Grid {
+	return NewGrid(SeionRows...)
+}
+
+// VoicedGrid returns the table of dakuten and handakuten rows
+func VoicedGrid() Grid {
+	return NewGrid(VoicedRows...)
+}
+
+// Cell returns the character at a row and column
+func (g Grid) Cell(row Row, column Column) (Character, bool) {
+	for i, r := range g.Rows {
+		if r != row {
+			continue
+		}
+		for j, c := range g.Columns {
+			if c == column && g.Cells[i][j] != nil {
+				return *g.Cells[i][j], true
+			}
+		}
+	}
+	return Character{}, false
+}
+
+// GetCharactersByRow returns the characters in a row in column order
+func GetCharactersByRow(row Row) []Character {
+	return defaultRegistry.ByRow(row)
+}
+
+// GetCharactersByColumn returns the characters in a column in gojūon order
+func GetCharactersByColumn(column Column) []Character {
+	return defaultRegistry.ByColumn(column)
+}
+
+// ParseRow parses a row name such as "ka" or "ka-row"
+func ParseRow(name string) (Row, bool) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "-row")
+	rows := make([]Row, 0, len(SeionRows)+len(VoicedRows)+1)
+	rows = append(rows, SeionRows...)
+	rows = append(rows, VoicedRows...)
+	rows = append(rows, RowN)
+	for _, row := range rows {
+		if string(row) == name {
+			return row, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,117 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestGridPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		hiragana   string
+		wantRow    Row
+		wantColumn Column
+	}{
+		{"Vowel", "あ", RowA, ColumnA},
+		{"Ka row", "き", RowKa, ColumnI},
+		{"Irregular shi", "し", RowSa, ColumnI},
+		{"Irregular tsu", "つ", RowTa, ColumnU},
+		{"Irregular fu", "ふ", RowHa, ColumnU},
+		{"Wo", "を", RowWa, ColumnO},
+		{"N", "ん", RowN, ""},
+		{"Voiced di", "ぢ", RowDa, ColumnI},
+		{"Half-voiced", "ぽ", RowPa, ColumnO},
+		{"Yoon has no position", "きゃ", "", ""},
+		{"Mark has no position", "っ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, _ := GetCharacterByHiragana(tt.hiragana)
+			if char.Row != tt.wantRow || char.Column != tt.wantColumn {
+				t.Errorf("position = (%v, %v), want (%v, %v)", char.Row, char.Column, tt.wantRow, tt.wantColumn)
+			}
+		})
+	}
+}
+
+func TestGojuonGrid(t *testing.T) {
+	grid := GojuonGrid()
+	if len(grid.Cells) != 10 {
+		t.Fatalf("GojuonGrid() rows = %v, want 10", len(grid.Cells))
+	}
+
+	filled := 0
+	for _, row := range grid.Cells {
+		if len(row) != 5 {
+			t.Fatalf("GojuonGrid() columns = %v, want 5", len(row))
+		}
+		for _, cell := range row {
+			if cell != nil {
+				filled++
+			}
+		}
+	}
+	if filled != 45 {
+		t.Errorf("GojuonGrid() filled cells = %v, want 45", filled)
+	}
+
+	gaps := []struct {
+		row    Row
+		column Column
+	}{
+		{RowYa, ColumnI},
+		{RowYa, ColumnE},
+		{RowWa, ColumnI},
+		{RowWa, ColumnU},
+		{RowWa, ColumnE},
+	}
+	for _, gap := range gaps {
+		if char, ok := grid.Cell(gap.row, gap.column); ok {
+			t.Errorf("Cell(%v, %v) = %v, want gap", gap.row, gap.column, char.Hiragana)
+		}
+	}
+
+	if char, ok := grid.Cell(RowTa, ColumnU); !ok || char.Hiragana != "つ" {
+		t.Errorf("Cell(ta, u) = %v, want つ", char.Hiragana)
+	}
+}
+
+func TestGetCharactersByRowAndColumn(t *testing.T) {
+	kaRow := GetCharactersByRow(RowKa)
+	want := []string{"か", "き", "く", "け", "こ"}
+	if len(kaRow) != len(want) {
+		t.Fatalf("GetCharactersByRow(ka) = %v, want %v", kaRow, want)
+	}
+	for i, char := range kaRow {
+		if char.Hiragana != want[i] {
+			t.Errorf("GetCharactersByRow(ka)[%d] = %v, want %v", i, char.Hiragana, want[i])
+		}
+	}
+
+	// 8 seion, 4 dakuon and 1 handakuon rows have an i-column kana
+	if got := len(GetCharactersByColumn(ColumnI)); got != 13 {
+		t.Errorf("GetCharactersByColumn(i) count = %v, want 13", got)
+	}
+}
+
+func TestParseRow(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      Row
+		wantFound bool
+	}{
+		{"ka", RowKa, true},
+		{"KA-row", RowKa, true},
+		{"pa", RowPa, true},
+		{"kya", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, found := ParseRow(tt.input)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("ParseRow() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package goju
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// kanaIPA holds the broad IPA transcription of each kana read on its own,
+// keyed by hiragana. Yoon are built from their i-column kana, see setIPA.
+var kanaIPA = map[string]string{
+	"あ": "a", "い": "i", "う": "ɯ", "え": "e", "お": "o",
+	"か": "ka", "き": "kʲi", "く": "kɯ", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "ɕi", "す": "sɯ", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "tɕi", "つ": "tsɯ", "て": "te", "と": "to",
+	"な": "na", "に": "ɲi", "ぬ": "nɯ", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "çi", "ふ": "ɸɯ", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mʲi", "む": "mɯ", "め": "me", "も": "mo",
+	"や": "ja", "ゆ": "jɯ", "よ": "jo",
+	"ら": "ɾa", "り": "ɾʲi", "る": "ɾɯ", "れ": "ɾe", "ろ": "ɾo",
+	"わ": "wa", "を": "o", "ん": "ɴ",
+	"が": "ɡa", "ぎ": "ɡʲi", "ぐ": "ɡɯ", "げ": "ɡe", "ご": "ɡo",
+	"ざ": "dza", "じ": "dʑi", "ず": "dzɯ", "ぜ": "dze", "ぞ": "dzo",
+	"だ": "da", "ぢ": "dʑi", "づ": "dzɯ", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bʲi", "ぶ": "bɯ", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pʲi", "ぷ": "pɯ", "ぺ": "pe", "ぽ": "po",
+	"ふぁ": "ɸa", "ふぃ": "ɸi", "ふぇ": "ɸe", "ふぉ": "ɸo", "ふゅ": "ɸʲɯ",
+	"てぃ": "ti", "とぅ": "tɯ", "てゅ": "tʲɯ",
+	"でぃ": "di", "どぅ": "dɯ", "でゅ": "dʲɯ",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo", "いぇ": "je",
+	"ゔぁ": "ba", "ゔぃ": "bi", "ゔ": "bɯ", "ゔぇ": "be", "ゔぉ": "bo",
+	"しぇ": "ɕe", "じぇ": "dʑe", "ちぇ": "tɕe",
+	"つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+	"くぁ": "kʷa", "ぐぁ": "ɡʷa",
+	"ぁ": "a", "ぃ": "i", "ぅ": "ɯ", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ja", "ゅ": "jɯ", "ょ": "jo", "ゎ": "wa",
+}
+
+// yoonVowels maps the small kana that form yoon to the vowel they add
+var yoonVowels = map[rune]string{'ゃ': "a", 'ゅ': "ɯ", 'ょ': "o"}
+
+// setIPA fills in the IPA of a character, keeping any already set by a
+// data file. Marks such as っ and ー have none on their own.
+func setIPA(char *Character) {
+	if char.IPA != "" {
+		return
+	}
+	if ipa, ok := kanaIPA[char.Hiragana]; ok {
+		char.IPA = ipa
+		return
+	}
+	// Yoon replace the i of their i-column kana, so きゃ is kʲ + a
+	runes := []rune(char.Hiragana)
+	if len(runes) != 2 {
+		return
+	}
+	base, ok := kanaIPA[string(runes[0])]
+	vowel, small := yoonVowels[runes[1]]
+	if ok && small && strings.HasSuffix(base, "i") {
+		char.IPA = strings.TrimSuffix(base, "i") + vowel
+	}
+}
+
+const (
+	ipaLong      = "ː"
+	ipaVoiceless = "̥" // combining ring below
+	ipaVowels    = "aiɯeo"
+
+	// ipaVoicelessOnsets are the first sounds of the voiceless consonants
+	ipaVoicelessOnsets = "kstɕçɸph"
+)
+
+// ipaSegment is the transcription of one token of the input
+type ipaSegment struct {
+	tok token
+	ipa string
+}
+
+// IPA transcribes a kana string into broad IPA using the default registry,
+// see Registry.IPA
+func IPA(s string) string {
+	return defaultRegistry.IPA(s)
+}
+
+// IPA transcribes a kana string into broad IPA. Unlike the IPA of single
+// characters, it applies the rules of connected speech:
+//   - ん takes the place of articulation of the next sound: m before p, b
+//     and m, n before t, d, ts, n and ɾ, ɲ before tɕ, dʑ and ɲ, ŋ before k
+//     and ɡ, and ɴ elsewhere
+//   - っ doubles the next consonant, and is a glottal stop before a vowel
+//     or at the end
+//   - ー and vowels that lengthen the one before them (ああ, おう, えい)
+//     become ː
+//   - i and ɯ between voiceless consonants, and ɯ of a final す, are
+//     devoiced, as in kʲi̥ta and desɯ̥
+//
+// Text that is not kana is copied unchanged.
+func (r *Registry) IPA(s string) string {
+	tokens := r.tokenize(s)
+	segments := make([]ipaSegment, len(tokens))
+	for i, tok := range tokens {
+		segments[i] = ipaSegment{tok: tok, ipa: tok.Char.IPA}
+		switch {
+		case tok.Kind == tokenUnknown:
+			segments[i].ipa = tok.Text
+		case tok.Char.ID == iterationMarkID && i > 0:
+			segments[i].ipa = segments[i-1].ipa
+		case tok.Char.ID == voicedIterationMarkID && i > 0:
+			if voiced, ok := voicedForm(tokens[i-1].Char); ok {
+				segments[i].ipa = voiced.IPA
+			}
+		}
+	}
+
+	// Sounds shaped by the next one
+	for i := len(segments) - 1; i >= 0; i-- {
+		next := ""
+		if i+1 < len(segments) && segments[i+1].tok.Kind != tokenUnknown {
+			next = segments[i+1].ipa
+		}
+		switch {
+		case segments[i].tok.Kind == tokenSokuon:
+			segments[i].ipa = geminateIPA(next)
+		case segments[i].tok.Char.Hiragana == "ん":
+			segments[i].ipa = nasalIPA(next)
+		}
+	}
+
+	// Sounds shaped by the one before
+	for i := 1; i < len(segments); i++ {
+		prev := segments[i-1].ipa
+		if segments[i-1].tok.Kind == tokenUnknown {
+			continue
+		}
+		switch {
+		case segments[i].tok.Kind == tokenChoonpu:
+			segments[i].ipa = ipaLong
+		case lengthens(prev, segments[i].tok.Char):
+			segments[i].ipa = ipaLong
+		}
+	}
+
+	// Devoicing looks at the final form of both neighbours
+	for i, segment := range segments {
+		if segment.tok.Kind != tokenKana || !devoiceable(segment.ipa) {
+			continue
+		}
+		last := i+1 == len(segments) || segments[i+1].tok.Kind == tokenUnknown
+		switch {
+		case last && segment.tok.Char.Hiragana == "す":
+		case !last && isVoicelessOnset(segments[i+1].ipa):
+		default:
+			continue
+		}
+		segments[i].ipa += ipaVoiceless
+	}
+
+	var sb strings.Builder
+	for _, segment := range segments {
+		sb.WriteString(segment.ipa)
+	}
+	return sb.String()
+}
+
+// geminateIPA returns the sound of っ before a sound
+func geminateIPA(next string) string {
+	first, _ := utf8.DecodeRuneInString(next)
+	if next == "" || strings.ContainsRune(ipaVowels, first) || next == ipaLong {
+		return "ʔ"
+	}
+	return string(first)
+}
+
+// nasalIPA returns the sound of ん before a sound
+func nasalIPA(next string) string {
+	switch {
+	case next == "":
+		return "ɴ"
+	case strings.HasPrefix(next, "tɕ"), strings.HasPrefix(next, "dʑ"), strings.HasPrefix(next, "ɲ"):
+		return "ɲ"
+	}
+	first, _ := utf8.DecodeRuneInString(next)
+	switch first {
+	case 'p', 'b', 'm':
+		return "m"
+	case 't', 'd', 'n', 'ɾ':
+		return "n"
+	case 'k', 'ɡ':
+		return "ŋ"
+	}
+	return "ɴ"
+}
+
+// lengthens reports whether a vowel kana lengthens the sound before it
+// rather than starting a new one
+func lengthens(prev string, char Character) bool {
+	if !isVowelKana(char) || prev == "" {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(prev)
+	vowel := char.IPA
+	return string(last) == vowel || (last == 'o' && vowel == "ɯ") || (last == 'e' && vowel == "i")
+}
+
+// devoiceable reports whether a mora is a voiceless consonant followed by
+// i or ɯ, the only vowels Japanese devoices
+func devoiceable(ipa string) bool {
+	return isVoicelessOnset(ipa) && (strings.HasSuffix(ipa, "i") || strings.HasSuffix(ipa, "ɯ"))
+}
+
+// isVoicelessOnset reports whether a sound starts with a voiceless consonant
+func isVoicelessOnset(ipa string) bool {
+	first, _ := utf8.DecodeRuneInString(ipa)
+	return ipa != "" && strings.ContainsRune(ipaVoicelessOnsets, first)
+}
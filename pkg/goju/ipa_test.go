@@ -0,0 +1,77 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestCharacterIPA(t *testing.T) {
+	tests := []struct {
+		kana string
+		want string
+	}{
+		{"ふ", "ɸɯ"},
+		{"し", "ɕi"},
+		{"ん", "ɴ"},
+		{"を", "o"},
+		{"りゅ", "ɾʲɯ"},
+		{"じょ", "dʑo"},
+		{"ティ", "ti"},
+		{"っ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			char, _ := DefaultRegistry().ByKana(tt.kana)
+			if char.IPA != tt.want {
+				t.Errorf("%s IPA = %v, want %v", tt.kana, char.IPA, tt.want)
+			}
+		})
+	}
+}
+
+func TestEveryReadableCharacterHasIPA(t *testing.T) {
+	for _, category := range []Category{Seion, Dakuon, Handaku, Yoon, Gairaigo} {
+		for _, char := range GetCharactersByCategory(category) {
+			if char.IPA == "" {
+				t.Errorf("%s has no IPA", char.ID)
+			}
+		}
+	}
+}
+
+func TestIPA(t *testing.T) {
+	tests := []struct {
+		name string
+		kana string
+		want string
+	}{
+		{"n before m", "さんま", "samma"},
+		{"n before b", "しんぶん", "ɕimbɯɴ"},
+		{"n before t", "あんない", "annai"},
+		{"n before tɕ", "こんにちは", "koɲɲitɕi̥ha"},
+		{"n before k", "ぎんこう", "ɡʲiŋkoː"},
+		{"n before w", "でんわ", "deɴwa"},
+		{"sokuon", "がっこう", "ɡakkoː"},
+		{"sokuon before ch", "まっちゃ", "mattɕa"},
+		{"sokuon at the end", "あっ", "aʔ"},
+		{"choonpu", "ラーメン", "ɾaːmeɴ"},
+		{"long vowels", "とうきょう", "toːkʲoː"},
+		{"ei", "せんせい", "seɴseː"},
+		{"repeated vowel", "おかあさん", "okaːsaɴ"},
+		{"devoiced i", "きた", "kʲi̥ta"},
+		{"devoiced u", "すし", "sɯ̥ɕi"},
+		{"final su", "です", "desɯ̥"},
+		{"voiced neighbour", "きぎ", "kʲiɡʲi"},
+		{"iteration mark", "こゝろ", "kokoɾo"},
+		{"katakana", "カメラ", "kameɾa"},
+		{"other text", "ねこ!", "neko!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IPA(tt.kana); got != tt.want {
+				t.Errorf("IPA(%s) = %v, want %v", tt.kana, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package goju
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CharacterSet is the content of a character data file
+type CharacterSet struct {
+	Characters []CharacterDef `yaml:"characters" json:"characters"`
+	Decks      []Deck         `yaml:"decks" json:"decks"`
+	Words      []Word         `yaml:"words" json:"words"`
+}
+
+// CharacterDef describes a character in a data file.
+// Kunrei and Nihon default to spellings derived from Romaji.
+type CharacterDef struct {
+	ID             string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Hiragana       string   `yaml:"hiragana" json:"hiragana"`
+	Katakana       string   `yaml:"katakana" json:"katakana"`
+	Romaji         string   `yaml:"romaji" json:"romaji"`
+	Kunrei         string   `yaml:"kunrei,omitempty" json:"kunrei,omitempty"`
+	Nihon          string   `yaml:"nihon,omitempty" json:"nihon,omitempty"`
+	Category       Category `yaml:"category" json:"category"`
+	Tags           []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Notes          string   `yaml:"notes,omitempty" json:"notes,omitempty"`
+	HiraganaOrigin string   `yaml:"hiragana_origin,omitempty" json:"hiragana_origin,omitempty"`
+	KatakanaOrigin string   `yaml:"katakana_origin,omitempty" json:"katakana_origin,omitempty"`
+	IPA            string   `yaml:"ipa,omitempty" json:"ipa,omitempty"`
+}
+
+// Deck is a named list of characters to study together. Entries may be
+// character IDs, kana in either script, or romaji.
+type Deck struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Characters  []string `yaml:"characters" json:"characters"`
+}
+
+// ValidationError lists the problems found in a character set
+type ValidationError struct {
+	Source   string
+	Problems []string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Source, strings.Join(e.Problems, "; "))
+}
+
+// Character converts the definition to a Character
+func (d CharacterDef) Character() Character {
+	return Character{
+		ID:             d.ID,
+		Hiragana:       d.Hiragana,
+		Katakana:       d.Katakana,
+		Romaji:         d.Romaji,
+		Kunrei:         d.Kunrei,
+		Nihon:          d.Nihon,
+		Category:       d.Category,
+		Tags:           d.Tags,
+		Notes:          d.Notes,
+		HiraganaOrigin: d.HiraganaOrigin,
+		KatakanaOrigin: d.KatakanaOrigin,
+		IPA:            d.IPA,
+	}
+}
+
+// ParseCharacterSet parses a character set in the given format ("yaml" or "json")
+func ParseCharacterSet(data []byte, format string) (*CharacterSet, error) {
+	set := &CharacterSet{}
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, set); err != nil {
+			return nil, err
+		}
+	case "json":
+		if err := json.Unmarshal(data, set); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data format %q", format)
+	}
+	return set, nil
+}
+
+// LoadCharacterSet reads a character set from a .yaml, .yml or .json file
+func LoadCharacterSet(path string) (*CharacterSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set, err := ParseCharacterSet(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return set, nil
+}
+
+// Validate checks the set on its own: every character needs kana, romaji
+// and a category, and no kana may appear twice
+func (s *CharacterSet) Validate() error {
+	var problems []string
+	hiragana := make(map[string]bool)
+	katakana := make(map[string]bool)
+
+	for i, def := range s.Characters {
+		label := fmt.Sprintf("character %d", i+1)
+		if def.Hiragana == "" && def.Katakana == "" {
+			problems = append(problems, fmt.Sprintf("%s has no kana", label))
+			continue
+		}
+		label = fmt.Sprintf("character %d (%s)", i+1, def.Hiragana+def.Katakana)
+		if def.Romaji == "" {
+			problems = append(problems, fmt.Sprintf("%s has no romaji", label))
+		}
+		if def.Category == "" {
+			problems = append(problems, fmt.Sprintf("%s has no category", label))
+		}
+		if def.Hiragana != "" {
+			if hiragana[def.Hiragana] {
+				problems = append(problems, fmt.Sprintf("duplicate hiragana %s", def.Hiragana))
+			}
+			hiragana[def.Hiragana] = true
+		}
+		if def.Katakana != "" {
+			if katakana[def.Katakana] {
+				problems = append(problems, fmt.Sprintf("duplicate katakana %s", def.Katakana))
+			}
+			katakana[def.Katakana] = true
+		}
+	}
+
+	decks := make(map[string]bool)
+	for i, deck := range s.Decks {
+		if deck.Name == "" {
+			problems = append(problems, fmt.Sprintf("deck %d has no name", i+1))
+			continue
+		}
+		if decks[deck.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate deck %s", deck.Name))
+		}
+		decks[deck.Name] = true
+	}
+
+	for i, word := range s.Words {
+		if word.Kana == "" {
+			problems = append(problems, fmt.Sprintf("word %d has no kana", i+1))
+			continue
+		}
+		if len(word.Meanings) == 0 {
+			problems = append(problems, fmt.Sprintf("word %d (%s) has no meanings", i+1, word.Kana))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Source: "character set", Problems: problems}
+	}
+	return nil
+}
+
+// Merge validates a character set against the registry and adds its
+// characters and decks. Nothing is added if any problem is found.
+func (r *Registry) Merge(set *CharacterSet) error {
+	if err := set.Validate(); err != nil {
+		return err
+	}
+
+	next := &Registry{
+		chars:      append([]Character(nil), r.chars...),
+		categories: append([]Category(nil), r.categories...),
+		decks:      make(map[string]Deck, len(r.decks)+len(set.Decks)),
+		words:      append(append([]Word(nil), r.words...), set.Words...),
+	}
+	for name, deck := range r.decks {
+		next.decks[name] = deck
+	}
+
+	var problems []string
+	for _, def := range set.Characters {
+		if _, exists := r.byHiragana[def.Hiragana]; exists && def.Hiragana != "" {
+			problems = append(problems, fmt.Sprintf("hiragana %s is already defined", def.Hiragana))
+		}
+		if _, exists := r.byKatakana[def.Katakana]; exists && def.Katakana != "" {
+			problems = append(problems, fmt.Sprintf("katakana %s is already defined", def.Katakana))
+		}
+		next.chars = append(next.chars, def.Character())
+		next.addCategory(def.Category)
+	}
+	next.sortByCategory()
+	next.reindex()
+
+	if len(next.byID) != len(next.chars) {
+		seen := make(map[string]bool)
+		for _, char := range next.chars {
+			if seen[char.ID] {
+				problems = append(problems, fmt.Sprintf("ID %s is already defined", char.ID))
+			}
+			seen[char.ID] = true
+		}
+	}
+
+	for _, deck := range set.Decks {
+		if _, exists := next.decks[deck.Name]; exists {
+			problems = append(problems, fmt.Sprintf("deck %s is already defined", deck.Name))
+			continue
+		}
+		for _, entry := range deck.Characters {
+			if _, ok := next.resolve(entry); !ok {
+				problems = append(problems, fmt.Sprintf("deck %s refers to unknown character %s", deck.Name, entry))
+			}
+		}
+		next.decks[deck.Name] = deck
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Source: "character set", Problems: problems}
+	}
+	*r = *next
+	return nil
+}
+
+// LoadDataDir merges every .yaml, .yml and .json file in dir into the
+// registry in file name order. A missing directory is not an error.
+func (r *Registry) LoadDataDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		set, err := LoadCharacterSet(path)
+		if err != nil {
+			return err
+		}
+		if err := r.Merge(set); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				verr.Source = path
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDataDir merges the data files in dir into the default registry
+func LoadDataDir(dir string) error {
+	return defaultRegistry.LoadDataDir(dir)
+}
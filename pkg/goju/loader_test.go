@@ -0,0 +1,169 @@
+package goju
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const obsoleteKanaYAML = `
+characters:
+  - hiragana: ゐ
+    katakana: ヰ
+    romaji: wi
+    kunrei: i
+    nihon: wi
+    category: obsolete
+    tags: [historical]
+    notes: Replaced by い in the 1946 spelling reform
+  - hiragana: ゑ
+    katakana: ヱ
+    romaji: we
+    kunrei: e
+    nihon: we
+    category: obsolete
+decks:
+  - name: week1
+    characters: [あ, カ, shi, seion-n, ゐ]
+`
+
+const regionalJSON = `{
+  "characters": [
+    {"hiragana": "くゎ", "katakana": "クヮ", "romaji": "kwa", "category": "regional"}
+  ]
+}`
+
+func TestParseCharacterSetValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"Valid set", obsoleteKanaYAML, false},
+		{"Missing romaji", "characters:\n  - {hiragana: ゐ, category: obsolete}", true},
+		{"Missing kana", "characters:\n  - {romaji: wi, category: obsolete}", true},
+		{"Missing category", "characters:\n  - {hiragana: ゐ, romaji: wi}", true},
+		{"Duplicate kana", "characters:\n  - {hiragana: ゐ, romaji: wi, category: a}\n  - {hiragana: ゐ, romaji: i, category: a}", true},
+		{"Unnamed deck", "decks:\n  - characters: [あ]", true},
+		{"Word without kana", "words:\n  - {meanings: {en: cat}}", true},
+		{"Word without meanings", "words:\n  - {kana: ねこ}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := ParseCharacterSet([]byte(tt.data), "yaml")
+			if err != nil {
+				t.Fatalf("ParseCharacterSet() error = %v", err)
+			}
+			err = set.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryMerge(t *testing.T) {
+	registry := NewRegistry(Characters)
+	set, err := ParseCharacterSet([]byte(obsoleteKanaYAML), "yaml")
+	if err != nil {
+		t.Fatalf("ParseCharacterSet() error = %v", err)
+	}
+	if err := registry.Merge(set); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	char, ok := registry.ByHiragana("ゐ")
+	if !ok {
+		t.Fatal("ByHiragana(ゐ) not found after Merge()")
+	}
+	if char.Category != "obsolete" || char.ID != "obsolete-wi" || char.Kunrei != "i" {
+		t.Errorf("merged character = %+v", char)
+	}
+	if len(char.Tags) != 1 || char.Notes == "" {
+		t.Errorf("merged character lost tags or notes: %+v", char)
+	}
+	if chars := registry.ByRomaji("e", Kunrei); len(chars) != 2 || chars[1].Katakana != "ヱ" {
+		t.Errorf("ByRomaji(e) = %v, want エ and ヱ", chars)
+	}
+
+	deck, ok := registry.Deck("week1")
+	if !ok || len(deck) != 5 {
+		t.Fatalf("Deck(week1) = %v, want 5 characters", deck)
+	}
+	want := []string{"あ", "か", "し", "ん", "ゐ"}
+	for i, char := range deck {
+		if char.Hiragana != want[i] {
+			t.Errorf("Deck(week1)[%d] = %v, want %v", i, char.Hiragana, want[i])
+		}
+	}
+
+	if _, ok := DefaultRegistry().ByHiragana("ゐ"); ok {
+		t.Error("Merge() into a new registry changed the default registry")
+	}
+}
+
+func TestRegistryMergeRejectsConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Existing kana", "characters:\n  - {hiragana: あ, katakana: ア, romaji: a, category: seion}"},
+		{"Unknown deck entry", "decks:\n  - {name: broken, characters: [あ, ゐ]}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(Characters)
+			before := registry.Len()
+			set, err := ParseCharacterSet([]byte(tt.data), "yaml")
+			if err != nil {
+				t.Fatalf("ParseCharacterSet() error = %v", err)
+			}
+
+			err = registry.Merge(set)
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Merge() error = %v, want *ValidationError", err)
+			}
+			if registry.Len() != before {
+				t.Errorf("Merge() changed the registry despite errors")
+			}
+		})
+	}
+}
+
+func TestRegistryLoadDataDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"obsolete.yaml": obsoleteKanaYAML,
+		"regional.json": regionalJSON,
+		"readme.txt":    "not a data file",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registry := NewRegistry(Characters)
+	if err := registry.LoadDataDir(dir); err != nil {
+		t.Fatalf("LoadDataDir() error = %v", err)
+	}
+	if _, ok := registry.ByKatakana("クヮ"); !ok {
+		t.Error("LoadDataDir() did not load the JSON file")
+	}
+	if _, ok := registry.ByKatakana("ヰ"); !ok {
+		t.Error("LoadDataDir() did not load the YAML file")
+	}
+
+	categories := registry.Categories()
+	if categories[len(categories)-1] != "regional" {
+		t.Errorf("Categories() = %v, want custom categories last", categories)
+	}
+
+	if err := NewRegistry(Characters).LoadDataDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("LoadDataDir() on a missing directory error = %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package goju
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mnemonic holds a memory aid for a character, keyed by language code
+// ("en", "zh" or "zh-tw")
+type Mnemonic map[string]string
+
+// languageFallbacks lists the languages tried, in order, when localized
+// text is missing in the requested language
+var languageFallbacks = map[string][]string{
+	"zh-tw": {"zh", "en"},
+	"zh":    {"en"},
+}
+
+// localized returns the text in the given language, falling back to a
+// related language and then English
+func localized(texts map[string]string, language string) string {
+	if text := texts[language]; text != "" {
+		return text
+	}
+	fallbacks, ok := languageFallbacks[language]
+	if !ok {
+		fallbacks = []string{"en"}
+	}
+	for _, fallback := range fallbacks {
+		if text := texts[fallback]; text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// Text returns the mnemonic in the given language or its nearest fallback
+func (m Mnemonic) Text(language string) string {
+	return localized(m, language)
+}
+
+// builtinMnemonics maps character IDs to mnemonics for the basic kana.
+// The Chinese text builds on the kanji each hiragana came from.
+var builtinMnemonics = map[string]Mnemonic{
+	"seion-a":  {"en": "An apple with a cross on top: a for apple", "zh": "“安”字草书，十字下面绕一圈，张嘴喊“啊”", "zh-tw": "「安」字草書，十字下面繞一圈，張嘴喊「啊」"},
+	"seion-i":  {"en": "Two eels swimming side by side: i as in eel", "zh": "“以”字草书，两笔像两个“1”，读“衣”", "zh-tw": "「以」字草書，兩筆像兩個「1」，讀「衣」"},
+	"seion-u":  {"en": "A bent back after a punch to the stomach: oof, u", "zh": "“宇”字草书，头上一点像屋顶，读“乌”", "zh-tw": "「宇」字草書，頭上一點像屋頂，讀「烏」"},
+	"seion-e":  {"en": "An exotic bird with a feather on its head: e for exotic", "zh": "“衣”字草书，像一件衣服，读“诶”", "zh-tw": "「衣」字草書，像一件衣服，讀「欸」"},
+	"seion-o":  {"en": "A golf club swinging at a ball: o as in golf", "zh": "“於”字草书，右上一点像飞出的球，读“哦”", "zh-tw": "「於」字草書，右上一點像飛出的球，讀「哦」"},
+	"seion-ka": {"en": "A blade striking with a spark: ka like a karate chop", "zh": "“加”字草书，左边是“力”，读“卡”", "zh-tw": "「加」字草書，左邊是「力」，讀「卡」"},
+	"seion-ki": {"en": "A key with two teeth: ki for key", "zh": "“几”字草书，像一把钥匙(key)", "zh-tw": "「幾」字草書，像一把鑰匙(key)"},
+	"seion-ku": {"en": "A cuckoo's open beak: ku for cuckoo", "zh": "“久”字的一部分，像张开的鸟嘴，读“哭”", "zh-tw": "「久」字的一部分，像張開的鳥嘴，讀「哭」"},
+	"seion-ke": {"en": "A keg lying next to its stand: ke for keg", "zh": "“计”字草书，左竖是“言”旁，像英文 keg", "zh-tw": "「計」字草書，左豎是「言」旁，像英文 keg"},
+	"seion-ko": {"en": "Two koi swimming in a pond", "zh": "“己”字草书的上下两笔，像两条锦鲤(koi)", "zh-tw": "「己」字草書的上下兩筆，像兩條錦鯉(koi)"},
+	"seion-sa": {"en": "A samurai's sword crossing a curved handle", "zh": "“左”字草书，读“撒”", "zh-tw": "「左」字草書，讀「撒」"},
+	"seion-si": {"en": "A fishing hook: she caught a fish", "zh": "“之”字草书，一笔像鱼钩，读“西”", "zh-tw": "「之」字草書，一筆像魚鉤，讀「西」"},
+	"seion-su": {"en": "A swing with a loop in its rope: su for swing", "zh": "“寸”字草书，中间打个结，读“斯”", "zh-tw": "「寸」字草書，中間打個結，讀「斯」"},
+	"seion-se": {"en": "A mouth with a fang, about to say something: se for say", "zh": "“世”字草书，形状和“世”几乎一样，读“塞”", "zh-tw": "「世」字草書，形狀和「世」幾乎一樣，讀「塞」"},
+	"seion-so": {"en": "A zigzag stitch: so for sew", "zh": "“曽”字草书，一笔折来折去像缝线，读“搜”", "zh-tw": "「曽」字草書，一筆折來折去像縫線，讀「搜」"},
+	"seion-ta": {"en": "The letters t and a written side by side", "zh": "“太”字草书，左边像字母 t，右边像 a，读“他”", "zh-tw": "「太」字草書，左邊像字母 t，右邊像 a，讀「他」"},
+	"seion-ti": {"en": "A cheerleader with one arm raised: chi for cheer", "zh": "“知”字草书，像反过来的数字 5，读“七”", "zh-tw": "「知」字草書，像反過來的數字 5，讀「七」"},
+	"seion-tu": {"en": "A tsunami wave curling over", "zh": "“川”字草书，像一道海浪(tsunami)", "zh-tw": "「川」字草書，像一道海浪(tsunami)"},
+	"seion-te": {"en": "A telescope tipped on its side: te for telescope", "zh": "“天”字草书，像横放的望远镜(telescope)", "zh-tw": "「天」字草書，像橫放的望遠鏡(telescope)"},
+	"seion-to": {"en": "A toe with a splinter stuck in it", "zh": "“止”字草书，像脚趾(toe)上扎了根刺", "zh-tw": "「止」字草書，像腳趾(toe)上扎了根刺"},
+	"seion-na": {"en": "A nun kneeling before a cross", "zh": "“奈”字草书，左边是十字，读“那”", "zh-tw": "「奈」字草書，左邊是十字，讀「那」"},
+	"seion-ni": {"en": "A knee beside a leg: ni for knee", "zh": "“仁”字草书，右边就是“二”，读“你”", "zh-tw": "「仁」字草書，右邊就是「二」，讀「你」"},
+	"seion-nu": {"en": "Chopsticks lifting noodles: nu for noodles", "zh": "“奴”字草书，像筷子夹面条(noodle)", "zh-tw": "「奴」字草書，像筷子夾麵條(noodle)"},
+	"seion-ne": {"en": "A cat curled up by a post: neko means cat", "zh": "“祢”字草书，尾巴卷起像猫(neko)", "zh-tw": "「禰」字草書，尾巴捲起像貓(neko)"},
+	"seion-no": {"en": "A no-entry sign, a circle with a slash", "zh": "“乃”字草书，像禁止通行的标志，读“诺”", "zh-tw": "「乃」字草書，像禁止通行的標誌，讀「諾」"},
+	"seion-ha": {"en": "Someone laughing ha by a wall", "zh": "“波”字草书，读“哈”", "zh-tw": "「波」字草書，讀「哈」"},
+	"seion-hi": {"en": "A wide grin: hee hee", "zh": "“比”字草书，像咧嘴笑“嘻嘻”", "zh-tw": "「比」字草書，像咧嘴笑「嘻嘻」"},
+	"seion-hu": {"en": "Mount Fuji with a cloud on each side", "zh": "“不”字草书，像两边有云的富士山(Fuji)", "zh-tw": "「不」字草書，像兩邊有雲的富士山(Fuji)"},
+	"seion-he": {"en": "A small hill: hey, climb it", "zh": "“部”字右边“阝”的草书，像小山坡，读“嘿”", "zh-tw": "「部」字右邊「阝」的草書，像小山坡，讀「嘿」"},
+	"seion-ho": {"en": "A house with a chimney: ho for home", "zh": "“保”字草书，右边像带烟囱的房子(home)", "zh-tw": "「保」字草書，右邊像帶煙囪的房子(home)"},
+	"seion-ma": {"en": "A mama with her arms out, holding a baby", "zh": "“末”字草书，两横一竖，读“妈”", "zh-tw": "「末」字草書，兩橫一豎，讀「媽」"},
+	"seion-mi": {"en": "A music note with a tail: mi in do-re-mi", "zh": "“美”字草书，读“咪”，就是 do-re-mi 的 mi", "zh-tw": "「美」字草書，讀「咪」，就是 do-re-mi 的 mi"},
+	"seion-mu": {"en": "A cow swishing its tail: moo", "zh": "“武”字草书，像一头牛在叫“哞”", "zh-tw": "「武」字草書，像一頭牛在叫「哞」"},
+	"seion-me": {"en": "An eye with a tear; me means eye", "zh": "“女”字草书，日语“め”就是眼睛", "zh-tw": "「女」字草書，日語「め」就是眼睛"},
+	"seion-mo": {"en": "A fish hook catching more fish", "zh": "“毛”字草书，和“毛”几乎一样，读“摸”", "zh-tw": "「毛」字草書，和「毛」幾乎一樣，讀「摸」"},
+	"seion-ya": {"en": "A yak with curved horns", "zh": "“也”字草书，像牦牛(yak)的角", "zh-tw": "「也」字草書，像犛牛(yak)的角"},
+	"seion-yu": {"en": "A fish swimming through a U-shaped pool", "zh": "“由”字草书，读“油”", "zh-tw": "「由」字草書，讀「油」"},
+	"seion-yo": {"en": "A yo-yo hanging from its string", "zh": "“与”字草书，像挂着的溜溜球(yo-yo)", "zh-tw": "「與」字草書，像掛著的溜溜球(yo-yo)"},
+	"seion-ra": {"en": "A rabbit with one ear up: ra for rabbit", "zh": "“良”字草书，头上一点像兔耳朵，读“拉”", "zh-tw": "「良」字草書，頭上一點像兔耳朵，讀「拉」"},
+	"seion-ri": {"en": "Two reeds growing by a river", "zh": "“利”字草书，右边是立刀旁，读“利”", "zh-tw": "「利」字草書，右邊是立刀旁，讀「利」"},
+	"seion-ru": {"en": "A route that loops back home at the end", "zh": "“留”字草书，尾巴绕一圈，读“噜”", "zh-tw": "「留」字草書，尾巴繞一圈，讀「嚕」"},
+	"seion-re": {"en": "Someone kneeling to pray, one leg bent", "zh": "“礼”字草书，像行礼时弯下的腿，读“累”", "zh-tw": "「禮」字草書，像行禮時彎下的腿，讀「累」"},
+	"seion-ro": {"en": "る with its loop cut off: a road with no roundabout", "zh": "“吕”字草书，比る少了尾圈，读“罗”", "zh-tw": "「呂」字草書，比る少了尾圈，讀「羅」"},
+	"seion-wa": {"en": "A swan spreading one wing over the water: wa", "zh": "“和”字草书，读“哇”，和ね、れ比较收尾", "zh-tw": "「和」字草書，讀「哇」，和ね、れ比較收尾"},
+	"seion-wo": {"en": "Someone bowing, whoa, holding a stick; used only as a particle", "zh": "“远”字草书，只用作助词，读“哦”", "zh-tw": "「遠」字草書，只用作助詞，讀「哦」"},
+	"seion-n":  {"en": "A handwritten lowercase n", "zh": "“无”字草书，像手写的字母 n", "zh-tw": "「無」字草書，像手寫的字母 n"},
+}
+
+// MnemonicStore combines the built-in mnemonics with the user's own. The
+// user's text takes precedence language by language.
+type MnemonicStore struct {
+	user map[string]Mnemonic
+}
+
+// NewMnemonicStore creates a store with only the built-in mnemonics
+func NewMnemonicStore() *MnemonicStore {
+	return &MnemonicStore{user: make(map[string]Mnemonic)}
+}
+
+var defaultMnemonics = NewMnemonicStore()
+
+// Get returns the mnemonic for a character ID
+func (s *MnemonicStore) Get(id string) (Mnemonic, bool) {
+	builtin, user := builtinMnemonics[id], s.user[id]
+	if len(builtin) == 0 && len(user) == 0 {
+		return nil, false
+	}
+	merged := make(Mnemonic, len(builtin)+len(user))
+	for language, text := range builtin {
+		merged[language] = text
+	}
+	for language, text := range user {
+		merged[language] = text
+	}
+	return merged, true
+}
+
+// Set records the user's own mnemonic for a character ID in one
+// language. An empty text removes it, restoring the built-in one.
+func (s *MnemonicStore) Set(id, language, text string) {
+	if text == "" {
+		delete(s.user[id], language)
+		if len(s.user[id]) == 0 {
+			delete(s.user, id)
+		}
+		return
+	}
+	if s.user[id] == nil {
+		s.user[id] = make(Mnemonic)
+	}
+	s.user[id][language] = text
+}
+
+// Load reads the user's mnemonics from a YAML file mapping character IDs
+// to text by language. A missing file is not an error.
+func (s *MnemonicStore) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	user := make(map[string]Mnemonic)
+	if err := yaml.Unmarshal(data, &user); err != nil {
+		return err
+	}
+	for id, mnemonic := range user {
+		for language, text := range mnemonic {
+			s.Set(id, language, text)
+		}
+	}
+	return nil
+}
+
+// Save writes the user's mnemonics to a YAML file
+func (s *MnemonicStore) Save(path string) error {
+	data, err := yaml.Marshal(s.user)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// GetMnemonic returns the mnemonic for a character ID
+func GetMnemonic(id string) (Mnemonic, bool) {
+	return defaultMnemonics.Get(id)
+}
+
+// SetMnemonic records the user's own mnemonic for a character ID
+func SetMnemonic(id, language, text string) {
+	defaultMnemonics.Set(id, language, text)
+}
+
+// LoadMnemonics reads the user's mnemonics into the default store
+func LoadMnemonics(path string) error {
+	return defaultMnemonics.Load(path)
+}
+
+// SaveMnemonics writes the user's mnemonics from the default store
+func SaveMnemonics(path string) error {
+	return defaultMnemonics.Save(path)
+}
@@ -0,0 +1,85 @@
+package goju
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEverySeionHasMnemonic(t *testing.T) {
+	for _, char := range GetCharactersByCategory(Seion) {
+		mnemonic, ok := GetMnemonic(char.ID)
+		if !ok {
+			t.Errorf("%s has no mnemonic", char.ID)
+			continue
+		}
+		for _, language := range []string{"en", "zh", "zh-tw"} {
+			if mnemonic[language] == "" {
+				t.Errorf("%s has no %s mnemonic", char.ID, language)
+			}
+		}
+	}
+}
+
+func TestMnemonicText(t *testing.T) {
+	mnemonic := Mnemonic{"en": "english", "zh": "简体"}
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"en", "english"},
+		{"zh", "简体"},
+		{"zh-tw", "简体"},
+		{"fr", "english"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			if got := mnemonic.Text(tt.language); got != tt.want {
+				t.Errorf("Text(%s) = %v, want %v", tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMnemonicStoreOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mnemonics.yaml")
+	store := NewMnemonicStore()
+	store.Set("seion-a", "en", "My own apple")
+	store.Set("obsolete-wi", "en", "A custom kana")
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded := NewMnemonicStore()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	mnemonic, _ := loaded.Get("seion-a")
+	if got := mnemonic.Text("en"); got != "My own apple" {
+		t.Errorf("Text(en) = %v, want the user's mnemonic", got)
+	}
+	if got := mnemonic.Text("zh"); got != builtinMnemonics["seion-a"]["zh"] {
+		t.Errorf("Text(zh) = %v, want the built-in mnemonic", got)
+	}
+	if _, ok := loaded.Get("obsolete-wi"); !ok {
+		t.Error("Get(obsolete-wi) not found after Load()")
+	}
+
+	loaded.Set("seion-a", "en", "")
+	mnemonic, _ = loaded.Get("seion-a")
+	if got := mnemonic.Text("en"); got != builtinMnemonics["seion-a"]["en"] {
+		t.Errorf("Text(en) after reset = %v, want the built-in mnemonic", got)
+	}
+
+	if err := NewMnemonicStore().Load(filepath.Join(t.TempDir(), "missing.yaml")); err != nil {
+		t.Errorf("Load() on a missing file error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("seion-a: [not, a, map]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewMnemonicStore().Load(path); err == nil {
+		t.Error("Load() on a malformed file error = nil")
+	}
+}
@@ -0,0 +1,140 @@
+package goju
+
+import (
+	"strings"
+)
+
+// Mora is one timing unit of a kana string. きゃ, っ, ー and ん are one
+// mora each. Start and End are byte offsets into the input.
+type Mora struct {
+	Text  string
+	Start int
+	End   int
+	Char  Character // the table entry, or the first one when a small kana was joined on
+	vowel byte      // Hepburn vowel the mora ends in, 0 for ん and っ
+}
+
+// Syllable is a mora together with the morae that lengthen or close it:
+// a long vowel, the second half of a diphthong, ん or っ. Start and End are
+// byte offsets into the input.
+type Syllable struct {
+	Text  string
+	Start int
+	End   int
+	Morae []Mora
+}
+
+// Morae splits a kana string into morae using the default registry
+func Morae(s string) []Mora {
+	return defaultRegistry.Morae(s)
+}
+
+// MoraCount returns the number of morae in a kana string
+func MoraCount(s string) int {
+	return len(Morae(s))
+}
+
+// Syllables splits a kana string into syllables using the default registry
+func Syllables(s string) []Syllable {
+	return defaultRegistry.Syllables(s)
+}
+
+// Morae splits a kana string into morae. A small kana that does not form
+// a table entry with the kana before it, as in くゎ, joins that kana's mora.
+// Text that is not kana, such as spaces, is skipped.
+func (r *Registry) Morae(s string) []Mora {
+	var morae []Mora
+	for _, tok := range r.tokenize(s) {
+		if tok.Kind == tokenUnknown {
+			continue
+		}
+		char := tok.Char
+		if tok.Kind != tokenKana {
+			char, _ = r.ByKana(tok.Text)
+		}
+
+		last := len(morae) - 1
+		if tok.Kind == tokenKana && isSmallKana(char) && last >= 0 && morae[last].End == tok.Start && morae[last].vowel != 0 {
+			morae[last].Text += tok.Text
+			morae[last].End = tok.End
+			morae[last].vowel = moraVowel(char)
+			continue
+		}
+
+		mora := Mora{Text: tok.Text, Start: tok.Start, End: tok.End, Char: char}
+		switch {
+		case tok.Kind == tokenSokuon:
+		case tok.Kind == tokenChoonpu, char.ID == iterationMarkID, char.ID == voicedIterationMarkID:
+			if last >= 0 {
+				mora.vowel = morae[last].vowel
+			}
+		default:
+			mora.vowel = moraVowel(char)
+		}
+		morae = append(morae, mora)
+	}
+	return morae
+}
+
+// Syllables splits a kana string into syllables. ん, っ and ー close the
+// syllable before them; a vowel kana does when it repeats the vowel (ああ),
+// lengthens it (おう, えい) or ends a diphthong in i (あい).
+func (r *Registry) Syllables(s string) []Syllable {
+	var syllables []Syllable
+	for _, mora := range r.Morae(s) {
+		last := len(syllables) - 1
+		if last >= 0 && closesSyllable(syllables[last].Morae, mora) {
+			syllables[last].Text = s[syllables[last].Start:mora.End]
+			syllables[last].End = mora.End
+			syllables[last].Morae = append(syllables[last].Morae, mora)
+			continue
+		}
+		syllables = append(syllables, Syllable{
+			Text:  mora.Text,
+			Start: mora.Start,
+			End:   mora.End,
+			Morae: []Mora{mora},
+		})
+	}
+	return syllables
+}
+
+// closesSyllable reports whether a mora belongs to the syllable made of
+// the given morae
+func closesSyllable(syllable []Mora, mora Mora) bool {
+	// A syllable has at most two morae and never starts with ん or っ
+	first := syllable[0]
+	if len(syllable) > 1 || first.vowel == 0 || first.End != mora.Start {
+		return false
+	}
+	switch {
+	case mora.Char.Romaji == "n" && mora.Char.Category == Seion,
+		mora.Text == sokuonHiragana || mora.Text == sokuonKatakana,
+		mora.Text == choonpu:
+		return true
+	case !isVowelKana(mora.Char):
+		return false
+	}
+	prev, next := first.vowel, mora.vowel
+	return prev == next || next == 'i' || (prev == 'o' && next == 'u')
+}
+
+// moraVowel returns the Hepburn vowel a character ends in, or 0
+func moraVowel(char Character) byte {
+	romaji := char.Romaji
+	if romaji == "" || !isVowel(romaji[len(romaji)-1]) {
+		return 0
+	}
+	return romaji[len(romaji)-1]
+}
+
+// isVowelKana reports whether a character is one of the plain vowels あいうえお
+func isVowelKana(char Character) bool {
+	return char.Category == Seion && len(char.Romaji) == 1 && isVowel(char.Romaji[0])
+}
+
+// isSmallKana reports whether a character is a small kana that modifies
+// the kana before it, such as ぁ or ゃ. The sokuon is not one.
+func isSmallKana(char Character) bool {
+	return char.Category == Modifier && char.Hiragana != sokuonHiragana && strings.Contains(smallKana, char.Hiragana)
+}
@@ -0,0 +1,70 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestMorae(t *testing.T) {
+	tests := []struct {
+		kana string
+		want []string
+	}{
+		{"きゃ", []string{"きゃ"}},
+		{"きって", []string{"き", "っ", "て"}},
+		{"ラーメン", []string{"ラ", "ー", "メ", "ン"}},
+		{"ふるいけや", []string{"ふ", "る", "い", "け", "や"}},
+		{"ヴォ", []string{"ヴォ"}},
+		{"くゎ", []string{"くゎ"}}, // small kana joins the kana before it
+		{"こゝろ", []string{"こ", "ゝ", "ろ"}},
+		{"ね こ", []string{"ね", "こ"}}, // spaces are skipped
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			morae := Morae(tt.kana)
+			if len(morae) != len(tt.want) {
+				t.Fatalf("Morae(%s) = %v, want %v", tt.kana, morae, tt.want)
+			}
+			for i, mora := range morae {
+				if mora.Text != tt.want[i] || tt.kana[mora.Start:mora.End] != mora.Text {
+					t.Errorf("Morae(%s)[%d] = %q at %d-%d, want %q", tt.kana, i, mora.Text, mora.Start, mora.End, tt.want[i])
+				}
+			}
+			if got := MoraCount(tt.kana); got != len(tt.want) {
+				t.Errorf("MoraCount(%s) = %d, want %d", tt.kana, got, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		kana string
+		want []string
+	}{
+		{"がっこう", []string{"がっ", "こう"}},
+		{"とうきょう", []string{"とう", "きょう"}},
+		{"せんせい", []string{"せん", "せい"}},
+		{"ラーメン", []string{"ラー", "メン"}},
+		{"おばあさん", []string{"お", "ばあ", "さん"}},
+		{"かいしゃ", []string{"かい", "しゃ"}},
+		{"かお", []string{"か", "お"}},
+		{"んん", []string{"ん", "ん"}},
+		{"ね こ", []string{"ね", "こ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			syllables := Syllables(tt.kana)
+			if len(syllables) != len(tt.want) {
+				t.Fatalf("Syllables(%s) = %v, want %v", tt.kana, syllables, tt.want)
+			}
+			for i, syllable := range syllables {
+				if syllable.Text != tt.want[i] || tt.kana[syllable.Start:syllable.End] != syllable.Text {
+					t.Errorf("Syllables(%s)[%d] = %q at %d-%d, want %q", tt.kana, i, syllable.Text, syllable.Start, syllable.End, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package goju
+
+import (
+	"unicode"
+)
+
+const (
+	combiningDakuten    = '\u3099'
+	combiningHandakuten = '\u309A'
+	spacingDakuten      = '\u309B' // ゛
+	spacingHandakuten   = '\u309C' // ゜
+	ideographicSpace    = '\u3000'
+	fullWidthOffset     = 0xFEE0 // distance from full-width ASCII to ASCII
+)
+
+// halfWidthKana and fullWidthKana pair the half-width katakana block
+// (U+FF61 to U+FF9F) with their full-width equivalents, rune for rune
+const (
+	halfWidthKana = "｡｢｣､･ｦｧｨｩｪｫｬｭｮｯｰｱｲｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄﾅﾆﾇﾈﾉﾊﾋﾌﾍﾎﾏﾐﾑﾒﾓﾔﾕﾖﾗﾘﾙﾚﾛﾜﾝﾞﾟ"
+	fullWidthKana = "。「」、・ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン゛゜"
+)
+
+var (
+	halfToFull = pairRunes(halfWidthKana, fullWidthKana)
+	fullToHalf = pairRunes(fullWidthKana, halfWidthKana)
+
+	// voicedComposition maps a kana to its form with dakuten
+	voicedComposition = composition(
+		"かきくけこさしすせそたちつてとはひふへほカキクケコサシスセソタチツテトハヒフヘホ", 1,
+		map[rune]rune{'う': 'ゔ', 'ウ': 'ヴ', 'ワ': 'ヷ', 'ヰ': 'ヸ', 'ヱ': 'ヹ', 'ヲ': 'ヺ', 'ゝ': 'ゞ', 'ヽ': 'ヾ'},
+	)
+
+	// semiVoicedComposition maps a kana to its form with handakuten
+	semiVoicedComposition = composition("はひふへほハヒフヘホ", 2, nil)
+)
+
+// pairRunes maps each rune of from to the rune at the same position in to
+func pairRunes(from, to string) map[rune]rune {
+	pairs := make(map[rune]rune)
+	toRunes := []rune(to)
+	for i, r := range []rune(from) {
+		pairs[r] = toRunes[i]
+	}
+	return pairs
+}
+
+// composition maps each base rune to the rune offset code points after it,
+// plus any irregular pairs
+func composition(bases string, offset rune, irregular map[rune]rune) map[rune]rune {
+	composed := make(map[rune]rune)
+	for _, r := range bases {
+		composed[r] = r + offset
+	}
+	for base, r := range irregular {
+		composed[base] = r
+	}
+	return composed
+}
+
+// needsNormalization reports whether Normalize would change r
+func needsNormalization(r rune) bool {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return true
+	case r >= 0xFF01 && r <= 0xFF9F:
+		return true
+	case r == combiningDakuten, r == combiningHandakuten, r == spacingDakuten, r == spacingHandakuten:
+		return true
+	case r == ideographicSpace:
+		return true
+	}
+	return false
+}
+
+// Normalize folds text into the form used by the character table so that
+// pasted or differently encoded input still matches. It composes kana with
+// a following dakuten or handakuten, whether combining (か + U+3099),
+// spacing (か゛) or half-width (ｶﾞ); widens half-width katakana; narrows
+// full-width ASCII; turns the ideographic space into a space; and folds
+// Latin letters to lower case. Marks that cannot be composed are kept.
+func Normalize(s string) string {
+	clean := true
+	for _, r := range s {
+		if needsNormalization(r) {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return s
+	}
+
+	var out []rune
+	for _, r := range s {
+		if full, ok := halfToFull[r]; ok {
+			r = full
+		} else if r >= 0xFF01 && r <= 0xFF5E {
+			r -= fullWidthOffset
+		} else if r == ideographicSpace {
+			r = ' '
+		}
+
+		var table map[rune]rune
+		switch r {
+		case combiningDakuten, spacingDakuten:
+			table = voicedComposition
+		case combiningHandakuten, spacingHandakuten:
+			table = semiVoicedComposition
+		}
+		if table != nil && len(out) > 0 {
+			if composed, ok := table[out[len(out)-1]]; ok {
+				out[len(out)-1] = composed
+				continue
+			}
+		}
+
+		if r >= 'A' && r <= 'Z' {
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
@@ -0,0 +1,81 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		// Already normalized input is returned unchanged
+		{"Plain hiragana", "ひらがな", "ひらがな"},
+		{"Plain romaji", "kana", "kana"},
+		{"Empty string", "", ""},
+
+		// Decomposed (NFD) text, as copied from many PDFs
+		{"Combining dakuten", "か\u3099", "が"},
+		{"Combining handakuten", "は\u309A", "ぱ"},
+		{"Combining dakuten katakana", "カ\u3099ラス", "ガラス"},
+		{"Combining dakuten on u", "ウ\u3099ァ", "ヴァ"},
+		{"Combining dakuten on iteration mark", "みすゝ\u3099", "みすゞ"},
+		{"NFD word", "て\u3099んし\u3099ゃ", "でんじゃ"},
+		{"Combining mark that cannot compose", "あ\u3099", "あ\u3099"},
+		{"Leading combining mark", "\u3099か", "\u3099か"},
+
+		// Spacing marks typed after the kana
+		{"Spacing dakuten", "か゛", "が"},
+		{"Spacing handakuten", "ほ゜", "ぽ"},
+
+		// Half-width katakana from legacy systems and receipts
+		{"Half-width katakana", "ｶﾀｶﾅ", "カタカナ"},
+		{"Half-width dakuten", "ｶﾞ", "ガ"},
+		{"Half-width handakuten", "ﾊﾟﾝ", "パン"},
+		{"Half-width small kana and long vowel", "ｷｬｯﾁｰ", "キャッチー"},
+		{"Half-width vu", "ｳﾞｧｲｵﾘﾝ", "ヴァイオリン"},
+		{"Half-width punctuation", "｢ｱ｣､ｲ｡", "「ア」、イ。"},
+
+		// Full-width ASCII typed with an IME left on
+		{"Full-width romaji", "ｋａ", "ka"},
+		{"Full-width upper case", "ＳＨＩ", "shi"},
+		{"Full-width apostrophe", "ｋｏｎ＇ｙａ", "kon'ya"},
+		{"Ideographic space", "ａ　ｉ", "a i"},
+
+		// Case folding
+		{"Upper-case romaji", "KYO", "kyo"},
+		{"Mixed case", "Tsu", "tsu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.expected {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookupsNormalizeInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup func() (Character, bool)
+		want   string
+	}{
+		{"Hiragana NFD", func() (Character, bool) { return GetCharacterByHiragana("し\u3099") }, "じ"},
+		{"Katakana half-width", func() (Character, bool) { return GetCharacterByKatakana("ｶﾞ") }, "が"},
+		{"Romaji full-width", func() (Character, bool) { return GetCharacterByRomaji("ｋａ") }, "か"},
+		{"Romaji upper case", func() (Character, bool) { return GetCharacterByRomaji("KA") }, "か"},
+		{"Codepoint half-width", func() (Character, bool) { return GetCharacterByCodepoint('ｱ') }, "あ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, ok := tt.lookup()
+			if !ok || char.Hiragana != tt.want {
+				t.Errorf("lookup = %v, %v, want %v", char.Hiragana, ok, tt.want)
+			}
+		})
+	}
+}
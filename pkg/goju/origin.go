@@ -0,0 +1,104 @@
+package goju
+
+import (
+	"strings"
+)
+
+// kanaOrigin holds the man'yōgana each script of a kana developed from
+type kanaOrigin struct {
+	hiragana string
+	katakana string
+}
+
+// kanaOrigins maps each basic kana to its source characters. Hiragana come
+// from cursive forms of the whole character, katakana from a part of it,
+// so the two often differ (あ from 安, ア from 阿).
+var kanaOrigins = map[string]kanaOrigin{
+	"あ": {"安", "阿"}, "い": {"以", "伊"}, "う": {"宇", "宇"}, "え": {"衣", "江"}, "お": {"於", "於"},
+	"か": {"加", "加"}, "き": {"幾", "幾"}, "く": {"久", "久"}, "け": {"計", "介"}, "こ": {"己", "己"},
+	"さ": {"左", "散"}, "し": {"之", "之"}, "す": {"寸", "須"}, "せ": {"世", "世"}, "そ": {"曽", "曽"},
+	"た": {"太", "多"}, "ち": {"知", "千"}, "つ": {"川", "川"}, "て": {"天", "天"}, "と": {"止", "止"},
+	"な": {"奈", "奈"}, "に": {"仁", "二"}, "ぬ": {"奴", "奴"}, "ね": {"祢", "祢"}, "の": {"乃", "乃"},
+	"は": {"波", "八"}, "ひ": {"比", "比"}, "ふ": {"不", "不"}, "へ": {"部", "部"}, "ほ": {"保", "保"},
+	"ま": {"末", "末"}, "み": {"美", "三"}, "む": {"武", "牟"}, "め": {"女", "女"}, "も": {"毛", "毛"},
+	"や": {"也", "也"}, "ゆ": {"由", "由"}, "よ": {"与", "與"},
+	"ら": {"良", "良"}, "り": {"利", "利"}, "る": {"留", "流"}, "れ": {"礼", "礼"}, "ろ": {"呂", "呂"},
+	"わ": {"和", "和"}, "を": {"遠", "乎"},
+	"ん": {"无", "尓"},
+	"ゐ": {"為", "井"}, "ゑ": {"恵", "恵"},
+}
+
+// smallKana lists the small hiragana, each one code point before its
+// full-size form
+const smallKana = "ぁぃぅぇぉっゃゅょゎ"
+
+// originBase returns the basic kana whose origin a single hiragana shares:
+// voiced and small kana inherit from the plain, full-size kana
+func originBase(hiragana string) string {
+	runes := []rune(hiragana)
+	if len(runes) != 1 {
+		return hiragana
+	}
+	r := runes[0]
+	if strings.ContainsRune(smallKana, r) {
+		return string(r + 1)
+	}
+	return Devoice(hiragana)
+}
+
+// setOrigin fills in the origin characters of single kana, keeping any
+// already set by a data file. Compound sounds and marks have none.
+func setOrigin(char *Character) {
+	if char.HiraganaOrigin != "" || char.KatakanaOrigin != "" {
+		return
+	}
+	origin, ok := kanaOrigins[originBase(char.Hiragana)]
+	if !ok {
+		return
+	}
+	char.HiraganaOrigin = origin.hiragana
+	char.KatakanaOrigin = origin.katakana
+}
+
+// OriginGroup lists the kana that developed from the same man'yōgana
+type OriginGroup struct {
+	Origin   string
+	Hiragana []Character // characters whose hiragana comes from Origin
+	Katakana []Character // characters whose katakana comes from Origin
+}
+
+// OriginGroups groups the basic kana by source character in gojūon order.
+// Voiced and small kana are left out since they share their base's origin.
+func (r *Registry) OriginGroups() []OriginGroup {
+	var groups []OriginGroup
+	index := make(map[string]int)
+	group := func(origin string) *OriginGroup {
+		i, ok := index[origin]
+		if !ok {
+			i = len(groups)
+			index[origin] = i
+			groups = append(groups, OriginGroup{Origin: origin})
+		}
+		return &groups[i]
+	}
+
+	for _, char := range r.chars {
+		if originBase(char.Hiragana) != char.Hiragana {
+			continue
+		}
+		if char.HiraganaOrigin != "" {
+			g := group(char.HiraganaOrigin)
+			g.Hiragana = append(g.Hiragana, char)
+		}
+		if char.KatakanaOrigin != "" {
+			g := group(char.KatakanaOrigin)
+			g.Katakana = append(g.Katakana, char)
+		}
+	}
+	return groups
+}
+
+// GetOriginGroups groups the basic kana by source character
+func GetOriginGroups() []OriginGroup {
+	return defaultRegistry.OriginGroups()
+}
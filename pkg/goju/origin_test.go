@@ -0,0 +1,73 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestCharacterOrigin(t *testing.T) {
+	tests := []struct {
+		name         string
+		kana         string
+		wantHiragana string
+		wantKatakana string
+	}{
+		{"Scripts differ", "あ", "安", "阿"},
+		{"Scripts share", "か", "加", "加"},
+		{"Dakuon inherits", "が", "加", "加"},
+		{"Handakuon inherits", "ぷ", "不", "不"},
+		{"Vu inherits", "ゔ", "宇", "宇"},
+		{"Small kana inherits", "っ", "川", "川"},
+		{"N", "ん", "无", "尓"},
+		{"Yoon has none", "きゃ", "", ""},
+		{"Mark has none", "ー", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, ok := GetCharacterByHiragana(tt.kana)
+			if !ok {
+				t.Fatalf("GetCharacterByHiragana(%s) not found", tt.kana)
+			}
+			if char.HiraganaOrigin != tt.wantHiragana || char.KatakanaOrigin != tt.wantKatakana {
+				t.Errorf("origin = (%v, %v), want (%v, %v)", char.HiraganaOrigin, char.KatakanaOrigin, tt.wantHiragana, tt.wantKatakana)
+			}
+		})
+	}
+}
+
+func TestEveryBasicKanaHasOrigin(t *testing.T) {
+	for _, category := range []Category{Seion, Dakuon, Handaku} {
+		for _, char := range GetCharactersByCategory(category) {
+			if char.HiraganaOrigin == "" || char.KatakanaOrigin == "" {
+				t.Errorf("%s has no origin", char.Hiragana)
+			}
+		}
+	}
+}
+
+func TestOriginGroups(t *testing.T) {
+	groups := GetOriginGroups()
+	byOrigin := make(map[string]OriginGroup, len(groups))
+	for _, group := range groups {
+		byOrigin[group.Origin] = group
+	}
+
+	if groups[0].Origin != "安" {
+		t.Errorf("first group = %v, want 安", groups[0].Origin)
+	}
+
+	ka := byOrigin["加"]
+	if len(ka.Hiragana) != 1 || ka.Hiragana[0].Hiragana != "か" || len(ka.Katakana) != 1 || ka.Katakana[0].Katakana != "カ" {
+		t.Errorf("group 加 = %+v, want か and カ", ka)
+	}
+	if a := byOrigin["阿"]; len(a.Hiragana) != 0 || len(a.Katakana) != 1 {
+		t.Errorf("group 阿 = %+v, want ア only", a)
+	}
+	for _, group := range groups {
+		for _, char := range append(group.Hiragana, group.Katakana...) {
+			if char.Category != Seion {
+				t.Errorf("group %s includes %s from %s", group.Origin, char.Hiragana, char.Category)
+			}
+		}
+	}
+}
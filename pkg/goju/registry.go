@@ -0,0 +1,348 @@
+package goju
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Registry holds every known character, category by category, together with
+// indexes for constant-time lookup by ID, kana, romaji and codepoint.
+// Kana and romaji lookups normalize their input first, see Normalize.
+type Registry struct {
+	chars       []Character
+	categories  []Category
+	decks       map[string]Deck
+	byID        map[string]int
+	byHiragana  map[string]int
+	byKatakana  map[string]int
+	byRomaji    map[RomanizationSystem]map[string][]int
+	byAnyRomaji map[string][]int
+	byCodepoint map[rune]int
+	byCategory  map[Category][]int
+	byRow       map[Row][]int
+	byColumn    map[Column][]int
+	maxLength   int
+	words       []Word
+	wordChars   [][]int       // characters spelling each word, nil if any kana is unknown
+	byWordChar  map[int][]int // words spelled with each character
+	similar     map[int][]similarEdge
+	romaji      *romajiIndex // spellings the romaji Converter accepts
+}
+
+// defaultRegistry is built from the compiled-in Characters and Words tables
+var defaultRegistry = newDefaultRegistry()
+
+func init() {
+	// Copy the derived fields back so callers ranging over Characters see
+	// the same IDs, romanizations and categories as the registry
+	i := 0
+	for _, category := range categoryOrder {
+		for j := range Characters[category] {
+			Characters[category][j] = defaultRegistry.chars[i]
+			i++
+		}
+	}
+}
+
+// newDefaultRegistry builds the registry used by the package-level lookups
+func newDefaultRegistry() *Registry {
+	r := NewRegistry(Characters)
+	r.AddWords(Words...)
+	return r
+}
+
+// DefaultRegistry returns the registry used by the package-level lookups
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// NewRegistry builds a registry from a character table. Categories are
+// visited in categoryOrder and entries keep their order within a category.
+// Every entry has its Category, romanizations, grid position, ID and Order
+// filled in.
+func NewRegistry(table map[Category][]Character) *Registry {
+	r := &Registry{
+		categories: append([]Category(nil), categoryOrder...),
+		decks:      make(map[string]Deck),
+	}
+	for _, category := range categoryOrder {
+		for _, char := range table[category] {
+			char.Category = category
+			r.chars = append(r.chars, char)
+		}
+	}
+	r.reindex()
+	return r
+}
+
+// CharacterID returns the stable identifier for a character, built from its
+// category and Nihon-shiki spelling, which is unique within a category
+func CharacterID(char Character) string {
+	return fmt.Sprintf("%s-%s", char.Category, char.Nihon)
+}
+
+// addCategory records a category the registry has not seen before
+func (r *Registry) addCategory(category Category) {
+	for _, known := range r.categories {
+		if known == category {
+			return
+		}
+	}
+	r.categories = append(r.categories, category)
+}
+
+// sortByCategory groups characters by category in registry category order,
+// keeping the order of characters within each category
+func (r *Registry) sortByCategory() {
+	rank := make(map[Category]int, len(r.categories))
+	for i, category := range r.categories {
+		rank[category] = i
+	}
+	sort.SliceStable(r.chars, func(a, b int) bool {
+		return rank[r.chars[a].Category] < rank[r.chars[b].Category]
+	})
+}
+
+// resolve finds a character by ID, kana or romaji
+func (r *Registry) resolve(ref string) (Character, bool) {
+	if char, ok := r.ByID(ref); ok {
+		return char, true
+	}
+	if char, ok := r.ByKana(ref); ok {
+		return char, true
+	}
+	if chars := r.ByAnyRomaji(ref); len(chars) > 0 {
+		return chars[0], true
+	}
+	return Character{}, false
+}
+
+// reindex fills in derived fields and rebuilds every index
+func (r *Registry) reindex() {
+	r.byID = make(map[string]int, len(r.chars))
+	r.byHiragana = make(map[string]int, len(r.chars))
+	r.byKatakana = make(map[string]int, len(r.chars))
+	r.byRomaji = make(map[RomanizationSystem]map[string][]int, len(RomanizationSystems))
+	r.byAnyRomaji = make(map[string][]int)
+	r.byCodepoint = make(map[rune]int)
+	r.byCategory = make(map[Category][]int)
+	r.byRow = make(map[Row][]int)
+	r.byColumn = make(map[Column][]int)
+	r.maxLength = 1
+
+	for _, system := range RomanizationSystems {
+		r.byRomaji[system] = make(map[string][]int)
+	}
+
+	for i := range r.chars {
+		char := &r.chars[i]
+		setRomanizations(char)
+		setGridPosition(char)
+		setOrigin(char)
+		setIPA(char)
+		if char.ID == "" {
+			char.ID = CharacterID(*char)
+		}
+		char.Order = i + 1
+
+		r.byID[char.ID] = i
+		r.byCategory[char.Category] = append(r.byCategory[char.Category], i)
+		if char.Row != "" {
+			r.byRow[char.Row] = append(r.byRow[char.Row], i)
+		}
+		if char.Column != "" {
+			r.byColumn[char.Column] = append(r.byColumn[char.Column], i)
+		}
+		for _, kana := range []string{char.Hiragana, char.Katakana} {
+			if kana == "" {
+				continue
+			}
+			n := utf8.RuneCountInString(kana)
+			if n > r.maxLength {
+				r.maxLength = n
+			}
+			if n == 1 {
+				cp, _ := utf8.DecodeRuneInString(kana)
+				if _, exists := r.byCodepoint[cp]; !exists {
+					r.byCodepoint[cp] = i
+				}
+			}
+		}
+		if _, exists := r.byHiragana[char.Hiragana]; !exists && char.Hiragana != "" {
+			r.byHiragana[char.Hiragana] = i
+		}
+		if _, exists := r.byKatakana[char.Katakana]; !exists && char.Katakana != "" {
+			r.byKatakana[char.Katakana] = i
+		}
+
+		seen := make(map[string]bool, len(RomanizationSystems))
+		for _, system := range RomanizationSystems {
+			spelling := char.RomajiIn(system)
+			if spelling == "" {
+				continue
+			}
+			r.byRomaji[system][spelling] = append(r.byRomaji[system][spelling], i)
+			if !seen[spelling] {
+				seen[spelling] = true
+				r.byAnyRomaji[spelling] = append(r.byAnyRomaji[spelling], i)
+			}
+		}
+	}
+
+	// Loanword kana come after native kana spelled the same, so di finds
+	// ぢ before ディ as the IME does, then the most frequent come first
+	rank := func(indexes []int) {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			x, y := r.chars[indexes[a]], r.chars[indexes[b]]
+			if (x.Category == Gairaigo) != (y.Category == Gairaigo) {
+				return y.Category == Gairaigo
+			}
+			return x.Frequency() > y.Frequency()
+		})
+	}
+	for _, index := range r.byRomaji {
+		for _, indexes := range index {
+			rank(indexes)
+		}
+	}
+	for _, indexes := range r.byAnyRomaji {
+		rank(indexes)
+	}
+	r.linkVoicing()
+	r.indexWords()
+	r.indexConfusables()
+	r.romaji = r.buildRomajiIndex()
+}
+
+// collect returns the characters at the given positions
+func (r *Registry) collect(indexes []int) []Character {
+	if len(indexes) == 0 {
+		return nil
+	}
+	chars := make([]Character, len(indexes))
+	for i, index := range indexes {
+		chars[i] = r.chars[index]
+	}
+	return chars
+}
+
+// get returns the character at a position found in one of the indexes
+func (r *Registry) get(index int, ok bool) (Character, bool) {
+	if !ok {
+		return Character{}, false
+	}
+	return r.chars[index], true
+}
+
+// Len returns the number of characters in the registry
+func (r *Registry) Len() int {
+	return len(r.chars)
+}
+
+// All returns every character in Order
+func (r *Registry) All() []Character {
+	chars := make([]Character, len(r.chars))
+	copy(chars, r.chars)
+	return chars
+}
+
+// ByID returns a character by its stable ID
+func (r *Registry) ByID(id string) (Character, bool) {
+	index, ok := r.byID[id]
+	return r.get(index, ok)
+}
+
+// ByHiragana returns a character by its hiragana representation
+func (r *Registry) ByHiragana(hiragana string) (Character, bool) {
+	index, ok := r.byHiragana[Normalize(hiragana)]
+	return r.get(index, ok)
+}
+
+// ByKatakana returns a character by its katakana representation
+func (r *Registry) ByKatakana(katakana string) (Character, bool) {
+	index, ok := r.byKatakana[Normalize(katakana)]
+	return r.get(index, ok)
+}
+
+// ByKana returns a character by its hiragana or katakana representation
+func (r *Registry) ByKana(kana string) (Character, bool) {
+	if char, ok := r.ByHiragana(kana); ok {
+		return char, true
+	}
+	return r.ByKatakana(kana)
+}
+
+// ByRomaji returns every character spelled romaji in the given system,
+// most frequent first
+func (r *Registry) ByRomaji(romaji string, system RomanizationSystem) []Character {
+	return r.collect(r.byRomaji[system][Normalize(romaji)])
+}
+
+// ByAnyRomaji returns every character spelled romaji in any supported
+// system, most frequent first
+func (r *Registry) ByAnyRomaji(romaji string) []Character {
+	return r.collect(r.byAnyRomaji[Normalize(romaji)])
+}
+
+// ByCodepoint returns the single-codepoint character for a hiragana or
+// katakana rune
+func (r *Registry) ByCodepoint(cp rune) (Character, bool) {
+	if full, ok := halfToFull[cp]; ok {
+		cp = full
+	}
+	index, ok := r.byCodepoint[cp]
+	return r.get(index, ok)
+}
+
+// ByCategory returns the characters in a category in gojūon order
+func (r *Registry) ByCategory(category Category) []Character {
+	return r.collect(r.byCategory[category])
+}
+
+// ByRow returns the characters in a gojūon row in column order
+func (r *Registry) ByRow(row Row) []Character {
+	return r.collect(r.byRow[row])
+}
+
+// ByColumn returns the characters in a gojūon column in gojūon order
+func (r *Registry) ByColumn(column Column) []Character {
+	return r.collect(r.byColumn[column])
+}
+
+// Categories returns every category in the registry, built-in ones first
+func (r *Registry) Categories() []Category {
+	return append([]Category(nil), r.categories...)
+}
+
+// Deck returns the characters of a named deck in deck order
+func (r *Registry) Deck(name string) ([]Character, bool) {
+	deck, ok := r.decks[name]
+	if !ok {
+		return nil, false
+	}
+	chars := make([]Character, 0, len(deck.Characters))
+	for _, ref := range deck.Characters {
+		if char, ok := r.resolve(ref); ok {
+			chars = append(chars, char)
+		}
+	}
+	return chars, true
+}
+
+// Decks returns every deck sorted by name
+func (r *Registry) Decks() []Deck {
+	decks := make([]Deck, 0, len(r.decks))
+	for _, deck := range r.decks {
+		decks = append(decks, deck)
+	}
+	sort.Slice(decks, func(a, b int) bool {
+		return decks[a].Name < decks[b].Name
+	})
+	return decks
+}
+
+// MaxKanaLength returns the length in runes of the longest kana entry
+func (r *Registry) MaxKanaLength() int {
+	return r.maxLength
+}
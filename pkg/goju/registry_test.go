@@ -0,0 +1,168 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestRegistryFillsDerivedFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		hiragana     string
+		wantID       string
+		wantCategory Category
+		wantOrder    int
+	}{
+		{"First seion", "あ", "seion-a", Seion, 1},
+		{"Last seion", "ん", "seion-n", Seion, 46},
+		{"Dakuon ji", "じ", "dakuon-zi", Dakuon, 53},
+		{"Dakuon di", "ぢ", "dakuon-di", Dakuon, 58},
+		{"Yoon", "きゃ", "yoon-kya", Yoon, 72},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, ok := GetCharacterByHiragana(tt.hiragana)
+			if !ok {
+				t.Fatalf("GetCharacterByHiragana(%q) not found", tt.hiragana)
+			}
+			if char.ID != tt.wantID {
+				t.Errorf("ID = %v, want %v", char.ID, tt.wantID)
+			}
+			if char.Category != tt.wantCategory {
+				t.Errorf("Category = %v, want %v", char.Category, tt.wantCategory)
+			}
+			if char.Order != tt.wantOrder {
+				t.Errorf("Order = %v, want %v", char.Order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestRegistryIDsAreUnique(t *testing.T) {
+	seen := make(map[string]string)
+	for _, char := range DefaultRegistry().All() {
+		if other, exists := seen[char.ID]; exists {
+			t.Errorf("ID %q shared by %s and %s", char.ID, other, char.Hiragana)
+		}
+		seen[char.ID] = char.Hiragana
+
+		byID, ok := GetCharacterByID(char.ID)
+		if !ok || byID.Hiragana != char.Hiragana {
+			t.Errorf("GetCharacterByID(%q) = %v, want %v", char.ID, byID.Hiragana, char.Hiragana)
+		}
+	}
+}
+
+func TestCharactersTableIsFilledIn(t *testing.T) {
+	for category, chars := range Characters {
+		for _, char := range chars {
+			if char.Category != category {
+				t.Errorf("%s has Category %q, want %q", char.Hiragana, char.Category, category)
+			}
+			want, ok := GetCharacterByID(char.ID)
+			if !ok {
+				t.Errorf("%s has ID %q, which the registry does not know", char.Hiragana, char.ID)
+				continue
+			}
+			if char.Kunrei != want.Kunrei || char.Nihon != want.Nihon || char.Order != want.Order {
+				t.Errorf("%s = %+v, want %+v", char.Hiragana, char, want)
+			}
+		}
+	}
+}
+
+func TestGetCharacterByCodepoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		cp        rune
+		wantKana  string
+		wantFound bool
+	}{
+		{"Hiragana", 'あ', "あ", true},
+		{"Katakana", 'ガ', "が", true},
+		{"Not kana", 'a', "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, found := GetCharacterByCodepoint(tt.cp)
+			if found != tt.wantFound {
+				t.Errorf("GetCharacterByCodepoint() found = %v, want %v", found, tt.wantFound)
+			}
+			if found && char.Hiragana != tt.wantKana {
+				t.Errorf("GetCharacterByCodepoint() = %v, want %v", char.Hiragana, tt.wantKana)
+			}
+		})
+	}
+}
+
+func TestGetCharactersByCategory(t *testing.T) {
+	tests := []struct {
+		category  Category
+		wantCount int
+	}{
+		{Seion, 46},
+		{Dakuon, 20},
+		{Handaku, 5},
+		{Yoon, 33},
+		{Gairaigo, 29},
+		{Modifier, 13},
+		{Category("unknown"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.category), func(t *testing.T) {
+			chars := GetCharactersByCategory(tt.category)
+			if len(chars) != tt.wantCount {
+				t.Errorf("GetCharactersByCategory() count = %v, want %v", len(chars), tt.wantCount)
+			}
+			for _, char := range chars {
+				if char.Category != tt.category {
+					t.Errorf("GetCharactersByCategory() returned %v in %v", char.Hiragana, char.Category)
+				}
+			}
+		})
+	}
+}
+
+// linearScanByHiragana mirrors the lookup that predates the registry
+func linearScanByHiragana(hiragana string) (Character, bool) {
+	for _, chars := range Characters {
+		for _, char := range chars {
+			if char.Hiragana == hiragana {
+				return char, true
+			}
+		}
+	}
+	return Character{}, false
+}
+
+var benchmarkInputs = []string{"あ", "ん", "ぼ", "ぴょ", "ああ"}
+
+func BenchmarkLinearScanByHiragana(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, input := range benchmarkInputs {
+			linearScanByHiragana(input)
+		}
+	}
+}
+
+func BenchmarkRegistryByHiragana(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, input := range benchmarkInputs {
+			GetCharacterByHiragana(input)
+		}
+	}
+}
+
+func BenchmarkRegistryByRomaji(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GetCharacterByRomaji("pyo")
+	}
+}
+
+func BenchmarkRegistryByCodepoint(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GetCharacterByCodepoint('ん')
+	}
+}
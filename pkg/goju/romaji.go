@@ -0,0 +1,226 @@
+package goju
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Script identifies one of the two kana scripts
+type Script string
+
+const (
+	ScriptHiragana Script = "hiragana"
+	ScriptKatakana Script = "katakana"
+)
+
+// imeAliases maps spellings that IMEs accept beyond the supported
+// romanization systems to the hiragana they produce
+var imeAliases = map[string]string{
+	"jya": "じゃ",
+	"jyu": "じゅ",
+	"jyo": "じょ",
+	"thi": "てぃ",
+	"thu": "てゅ",
+	"dhi": "でぃ",
+	"dhu": "でゅ",
+	"twu": "とぅ",
+	"dwu": "どぅ",
+}
+
+// romajiIndex maps every accepted romaji spelling to its table entry
+type romajiIndex struct {
+	chars       map[string]Character
+	small       map[string]Character
+	charsPrefix map[string]bool // proper prefixes of the spellings in chars
+	smallPrefix map[string]bool // proper prefixes of the spellings in small
+	maxLength   int
+}
+
+// buildRomajiIndex builds the romaji index of the registry. It runs once
+// per reindex, and every Converter shares the result.
+func (r *Registry) buildRomajiIndex() *romajiIndex {
+	idx := &romajiIndex{
+		chars:       make(map[string]Character),
+		small:       make(map[string]Character),
+		charsPrefix: make(map[string]bool),
+		smallPrefix: make(map[string]bool),
+	}
+	// Earlier categories win, so ti types ち rather than ティ
+	for _, char := range r.chars {
+		for _, system := range RomanizationSystems {
+			spelling := char.RomajiIn(system)
+			if spelling == "" {
+				continue
+			}
+			if _, exists := idx.chars[spelling]; !exists {
+				idx.chars[spelling] = char
+			}
+			// Small kana are spelled with an x prefix, and IMEs accept l too
+			if char.Category == Modifier && strings.HasPrefix(spelling, "x") {
+				idx.small[spelling[1:]] = char
+			}
+		}
+	}
+	for alias, hiragana := range imeAliases {
+		if char, ok := r.ByHiragana(hiragana); ok {
+			if _, exists := idx.chars[alias]; !exists {
+				idx.chars[alias] = char
+			}
+		}
+	}
+	for romaji := range idx.chars {
+		addPrefixes(idx.charsPrefix, romaji)
+		if len(romaji) > idx.maxLength {
+			idx.maxLength = len(romaji)
+		}
+	}
+	for romaji := range idx.small {
+		addPrefixes(idx.smallPrefix, romaji)
+	}
+	return idx
+}
+
+// addPrefixes adds every proper prefix of key, the empty one included, to set
+func addPrefixes(set map[string]bool, key string) {
+	for i := 0; i < len(key); i++ {
+		set[key[:i]] = true
+	}
+}
+
+// kana returns the character in the requested script
+func kana(char Character, script Script) string {
+	if script == ScriptKatakana {
+		return char.Katakana
+	}
+	return char.Hiragana
+}
+
+// isConsonant reports whether b is a romaji consonant
+func isConsonant(b byte) bool {
+	return b >= 'a' && b <= 'z' && !isVowel(b)
+}
+
+// Converter converts romaji to kana incrementally, the way an IME does.
+// Input is fed with Write; Output holds the kana converted so far and
+// Pending holds the romaji that cannot be resolved until more input arrives.
+type Converter struct {
+	script  Script
+	index   *romajiIndex
+	output  strings.Builder
+	pending string
+}
+
+// NewConverter creates a converter that produces kana in the given script
+func NewConverter(script Script) *Converter {
+	return &Converter{
+		script: script,
+		index:  defaultRegistry.romaji,
+	}
+}
+
+// Write feeds romaji into the converter
+func (c *Converter) Write(romaji string) {
+	c.pending += strings.ToLower(romaji)
+	for c.step(false) {
+	}
+}
+
+// Output returns the kana converted so far
+func (c *Converter) Output() string {
+	return c.output.String()
+}
+
+// Pending returns the romaji still waiting for more input
+func (c *Converter) Pending() string {
+	return c.pending
+}
+
+// String returns the converted kana followed by the pending romaji,
+// suitable for showing partial conversion while the user is typing
+func (c *Converter) String() string {
+	return c.output.String() + c.pending
+}
+
+// Flush resolves any pending romaji and returns the complete output.
+// A trailing n becomes ん and anything else is copied unchanged.
+func (c *Converter) Flush() string {
+	for c.step(true) {
+	}
+	return c.output.String()
+}
+
+// Reset clears the converter so it can be reused
+func (c *Converter) Reset() {
+	c.output.Reset()
+	c.pending = ""
+}
+
+// step consumes one unit of pending input and reports whether it made
+// progress. When final is set, no more input is expected.
+func (c *Converter) step(final bool) bool {
+	buf := c.pending
+	if buf == "" {
+		return false
+	}
+
+	consume := func(n int, out string) bool {
+		c.output.WriteString(out)
+		c.pending = c.pending[n:]
+		return true
+	}
+	n := c.index.chars["n"]
+
+	// Sequences that need a second character to decide
+	if len(buf) == 1 {
+		if !final && c.index.charsPrefix[buf] {
+			return false
+		}
+	} else {
+		switch {
+		case buf[0] == 'n' && (buf[1] == 'n' || buf[1] == '\''):
+			return consume(2, kana(n, c.script))
+		case buf[0] == 'n' && isConsonant(buf[1]) && buf[1] != 'y':
+			return consume(1, kana(n, c.script))
+		case buf[0] == buf[1] && isConsonant(buf[0]):
+			return consume(1, kana(c.index.small["tsu"], c.script))
+		case buf == "tc" && !final:
+			return false
+		case strings.HasPrefix(buf, "tch"):
+			return consume(1, kana(c.index.small["tsu"], c.script))
+		}
+	}
+
+	// Small kana typed with an x or l prefix
+	if buf[0] == 'x' || buf[0] == 'l' {
+		rest := buf[1:]
+		if !final && c.index.smallPrefix[rest] {
+			return false
+		}
+		for length := len(rest); length > 0; length-- {
+			if char, ok := c.index.small[rest[:length]]; ok {
+				return consume(1+length, kana(char, c.script))
+			}
+		}
+	}
+
+	// Wait while the buffer could still grow into a longer spelling
+	if !final && c.index.charsPrefix[buf] {
+		return false
+	}
+
+	for length := min(len(buf), c.index.maxLength); length > 0; length-- {
+		if char, ok := c.index.chars[buf[:length]]; ok {
+			return consume(length, kana(char, c.script))
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(buf)
+	return consume(size, buf[:size])
+}
+
+// ToKana converts a complete romaji string to kana in the given script
+func ToKana(romaji string, script Script) string {
+	c := NewConverter(script)
+	c.Write(romaji)
+	return c.Flush()
+}
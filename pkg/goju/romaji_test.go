@@ -0,0 +1,122 @@
+package goju
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToKana(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		script   Script
+		expected string
+	}{
+		{"Single vowel", "a", ScriptHiragana, "あ"},
+		{"Word", "hiragana", ScriptHiragana, "ひらがな"},
+		{"Double consonant", "gakkou", ScriptHiragana, "がっこう"},
+		{"Double consonant before ch", "matcha", ScriptHiragana, "まっちゃ"},
+		{"Apostrophe n", "kon'nichiwa", ScriptHiragana, "こんにちわ"},
+		{"Double n", "nn", ScriptHiragana, "ん"},
+		{"Trailing n", "hon", ScriptHiragana, "ほん"},
+		{"N before consonant", "sanpo", ScriptHiragana, "さんぽ"},
+		{"Yoon", "kyouto", ScriptHiragana, "きょうと"},
+		{"Small tsu with xtu", "xtu", ScriptHiragana, "っ"},
+		{"Small tsu with ltsu", "ltsu", ScriptHiragana, "っ"},
+		{"Small ya", "xya", ScriptHiragana, "ゃ"},
+		{"IME alias", "situ", ScriptHiragana, "しつ"},
+		{"Katakana", "robotto", ScriptKatakana, "ロボット"},
+		{"Katakana long vowel", "ra-men", ScriptKatakana, "ラーメン"},
+		{"Uppercase", "KANA", ScriptHiragana, "かな"},
+		{"Loanword", "fairu", ScriptKatakana, "ファイル"},
+		{"Native ti wins", "ti", ScriptHiragana, "ち"},
+		{"Loanword alias", "pa-thi-", ScriptKatakana, "パーティー"},
+		{"Unconvertible", "q1", ScriptHiragana, "q1"},
+		{"Empty string", "", ScriptHiragana, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToKana(tt.input, tt.script)
+			if got != tt.expected {
+				t.Errorf("ToKana() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConverterStreaming(t *testing.T) {
+	steps := []struct {
+		input   string
+		output  string
+		pending string
+	}{
+		{"k", "", "k"},
+		{"y", "", "ky"},
+		{"o", "きょ", ""},
+		{"n", "きょ", "n"},
+		{"n", "きょん", ""},
+		{"t", "きょん", "t"},
+		{"t", "きょんっ", "t"},
+		{"e", "きょんって", ""},
+	}
+
+	c := NewConverter(ScriptHiragana)
+	for _, step := range steps {
+		c.Write(step.input)
+		if c.Output() != step.output || c.Pending() != step.pending {
+			t.Errorf("after %q: Output() = %q, Pending() = %q, want %q, %q",
+				step.input, c.Output(), c.Pending(), step.output, step.pending)
+		}
+	}
+
+	c.Reset()
+	c.Write("kan")
+	if got := c.String(); got != "かn" {
+		t.Errorf("String() = %q, want %q", got, "かn")
+	}
+	if got := c.Flush(); got != "かん" {
+		t.Errorf("Flush() = %q, want %q", got, "かん")
+	}
+}
+
+func TestRomajiIndexPrefixes(t *testing.T) {
+	idx := defaultRegistry.romaji
+	tests := []struct {
+		name   string
+		prefix map[string]bool
+		keys   map[string]Character
+	}{
+		{"Spellings", idx.charsPrefix, idx.chars},
+		{"Small spellings", idx.smallPrefix, idx.small},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every string that is one letter short of a key, or a key
+			// itself, must agree with a scan of the keys
+			candidates := map[string]bool{"": true, "q": true}
+			for key := range tt.keys {
+				for i := 0; i <= len(key); i++ {
+					candidates[key[:i]] = true
+				}
+			}
+			for candidate := range candidates {
+				want := false
+				for key := range tt.keys {
+					if len(key) > len(candidate) && strings.HasPrefix(key, candidate) {
+						want = true
+						break
+					}
+				}
+				if got := tt.prefix[candidate]; got != want {
+					t.Errorf("prefix[%q] = %v, want %v", candidate, got, want)
+				}
+			}
+		})
+	}
+
+	if NewConverter(ScriptHiragana).index != NewConverter(ScriptKatakana).index {
+		t.Error("NewConverter() built a new romaji index; want the registry's")
+	}
+}
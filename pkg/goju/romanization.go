@@ -0,0 +1,113 @@
+package goju
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RomanizationSystem represents a system for writing kana in Latin letters
+type RomanizationSystem string
+
+const (
+	Hepburn RomanizationSystem = "hepburn" // ヘボン式
+	Kunrei  RomanizationSystem = "kunrei"  // 訓令式
+	Nihon   RomanizationSystem = "nihon"   // 日本式
+)
+
+// RomanizationSystems lists the supported systems in display order
+var RomanizationSystems = []RomanizationSystem{Hepburn, Kunrei, Nihon}
+
+// kunreiSpellings maps Hepburn spellings to their Kunrei-shiki equivalents
+// where the two systems differ
+var kunreiSpellings = map[string]string{
+	"shi":  "si",
+	"chi":  "ti",
+	"tsu":  "tu",
+	"fu":   "hu",
+	"ji":   "zi",
+	"sha":  "sya",
+	"shu":  "syu",
+	"sho":  "syo",
+	"cha":  "tya",
+	"chu":  "tyu",
+	"cho":  "tyo",
+	"ja":   "zya",
+	"ju":   "zyu",
+	"jo":   "zyo",
+	"she":  "sye",
+	"che":  "tye",
+	"je":   "zye",
+	"xtsu": "xtu",
+}
+
+// kunreiKana maps hiragana to their Kunrei-shiki spellings where the Hepburn
+// spelling alone does not determine them: wo is を, spelled o, but also the
+// loanword うぉ, which keeps wo
+var kunreiKana = map[string]string{
+	"を": "o",
+}
+
+// nihonSpellings maps hiragana to their Nihon-shiki spellings where they
+// differ from Kunrei-shiki
+var nihonSpellings = map[string]string{
+	"ぢ": "di",
+	"づ": "du",
+	"を": "wo",
+}
+
+// setRomanizations fills in the Kunrei and Nihon spellings from the Hepburn one
+func setRomanizations(char *Character) {
+	if char.Kunrei == "" {
+		char.Kunrei = char.Romaji
+		if spelling, ok := kunreiSpellings[char.Romaji]; ok {
+			char.Kunrei = spelling
+		}
+		if spelling, ok := kunreiKana[char.Hiragana]; ok {
+			char.Kunrei = spelling
+		}
+	}
+	if char.Nihon == "" {
+		char.Nihon = char.Kunrei
+		if spelling, ok := nihonSpellings[char.Hiragana]; ok {
+			char.Nihon = spelling
+		}
+	}
+}
+
+// ParseRomanizationSystem parses a system name such as "hepburn" or "kunrei".
+// An empty name selects Hepburn.
+func ParseRomanizationSystem(name string) (RomanizationSystem, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "hepburn":
+		return Hepburn, nil
+	case "kunrei", "kunrei-shiki":
+		return Kunrei, nil
+	case "nihon", "nihon-shiki", "nippon":
+		return Nihon, nil
+	default:
+		return "", fmt.Errorf("unknown romanization system %q", name)
+	}
+}
+
+// RomajiIn returns the romanization of the character in the given system.
+// Unknown systems fall back to Hepburn.
+func (c Character) RomajiIn(system RomanizationSystem) string {
+	switch system {
+	case Kunrei:
+		return c.Kunrei
+	case Nihon:
+		return c.Nihon
+	default:
+		return c.Romaji
+	}
+}
+
+// GetCharacterByRomajiIn returns a character by its romaji in the given system.
+// When several characters share the spelling the most frequent is returned.
+func GetCharacterByRomajiIn(romaji string, system RomanizationSystem) (Character, bool) {
+	matches := FindByRomajiIn(romaji, system)
+	if len(matches) == 0 {
+		return Character{}, false
+	}
+	return matches[0], true
+}
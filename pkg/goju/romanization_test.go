@@ -0,0 +1,95 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestRomajiIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		hiragana string
+		system   RomanizationSystem
+		expected string
+	}{
+		{"Hepburn shi", "し", Hepburn, "shi"},
+		{"Kunrei shi", "し", Kunrei, "si"},
+		{"Nihon shi", "し", Nihon, "si"},
+		{"Kunrei tsu", "つ", Kunrei, "tu"},
+		{"Kunrei fu", "ふ", Kunrei, "hu"},
+		{"Kunrei ji", "じ", Kunrei, "zi"},
+		{"Kunrei di", "ぢ", Kunrei, "zi"},
+		{"Nihon di", "ぢ", Nihon, "di"},
+		{"Nihon du", "づ", Nihon, "du"},
+		{"Kunrei wo", "を", Kunrei, "o"},
+		{"Nihon wo", "を", Nihon, "wo"},
+		{"Kunrei sha", "しゃ", Kunrei, "sya"},
+		{"Unchanged", "か", Nihon, "ka"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, ok := GetCharacterByHiragana(tt.hiragana)
+			if !ok {
+				t.Fatalf("GetCharacterByHiragana(%q) not found", tt.hiragana)
+			}
+			if got := char.RomajiIn(tt.system); got != tt.expected {
+				t.Errorf("RomajiIn() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetCharacterByRomajiIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		romaji    string
+		system    RomanizationSystem
+		wantKana  string
+		wantFound bool
+	}{
+		{"Kunrei si", "si", Kunrei, "し", true},
+		{"Nihon di", "di", Nihon, "ぢ", true},
+		{"Hepburn shi", "shi", Hepburn, "し", true},
+		{"Kunrei rejects Hepburn", "shi", Kunrei, "", false},
+		{"Hepburn rejects Kunrei", "si", Hepburn, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, found := GetCharacterByRomajiIn(tt.romaji, tt.system)
+			if found != tt.wantFound {
+				t.Errorf("GetCharacterByRomajiIn() found = %v, want %v", found, tt.wantFound)
+			}
+			if found && char.Hiragana != tt.wantKana {
+				t.Errorf("GetCharacterByRomajiIn() = %v, want %v", char.Hiragana, tt.wantKana)
+			}
+		})
+	}
+}
+
+func TestParseRomanizationSystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected RomanizationSystem
+		wantErr  bool
+	}{
+		{"Empty defaults to Hepburn", "", Hepburn, false},
+		{"Kunrei", "kunrei", Kunrei, false},
+		{"Nihon-shiki", "Nihon-shiki", Nihon, false},
+		{"Unknown", "wapuro", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRomanizationSystem(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRomanizationSystem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseRomanizationSystem() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
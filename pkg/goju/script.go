@@ -0,0 +1,103 @@
+package goju
+
+import (
+	"strings"
+)
+
+const (
+	// scriptOffset is the distance from a hiragana to its katakana code point
+	scriptOffset = 'ア' - 'あ'
+
+	halfWidthDakuten    = 'ﾞ'
+	halfWidthHandakuten = 'ﾟ'
+)
+
+// halfWidthDecomposition maps voiced katakana to the half-width base kana
+// and mark that spell them, such as ガ to ｶﾞ
+var halfWidthDecomposition = decomposition()
+
+// decomposition reverses the composition tables for every katakana whose
+// base has a half-width form
+func decomposition() map[rune]string {
+	pairs := make(map[rune]string)
+	for base, composed := range voicedComposition {
+		if half, ok := fullToHalf[base]; ok {
+			pairs[composed] = string([]rune{half, halfWidthDakuten})
+		}
+	}
+	for base, composed := range semiVoicedComposition {
+		if half, ok := fullToHalf[base]; ok {
+			pairs[composed] = string([]rune{half, halfWidthHandakuten})
+		}
+	}
+	return pairs
+}
+
+// isConvertibleHiragana reports whether r is a hiragana with a katakana
+// counterpart at the same offset (ぁ to ゖ, plus the iteration marks)
+func isConvertibleHiragana(r rune) bool {
+	return (r >= 'ぁ' && r <= 'ゖ') || r == 'ゝ' || r == 'ゞ'
+}
+
+// isConvertibleKatakana reports whether r is a katakana with a hiragana
+// counterpart at the same offset
+func isConvertibleKatakana(r rune) bool {
+	return (r >= 'ァ' && r <= 'ヶ') || r == 'ヽ' || r == 'ヾ'
+}
+
+// ToKatakana converts the hiragana in s to katakana. Other text, including
+// kanji, romaji and punctuation, is left unchanged.
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isConvertibleHiragana(r) {
+			return r + scriptOffset
+		}
+		return r
+	}, s)
+}
+
+// ToHiragana converts the katakana in s to hiragana. Katakana with no
+// hiragana form, such as ヷ, and the long vowel mark ー are kept.
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isConvertibleKatakana(r) {
+			return r - scriptOffset
+		}
+		return r
+	}, s)
+}
+
+// ToHalfWidth converts the full-width katakana and long vowel marks in s to
+// their half-width forms, splitting voiced kana into base and mark (ガ to
+// ｶﾞ). Other text, including hiragana and punctuation, is left unchanged;
+// convert hiragana with ToKatakana first if needed.
+func ToHalfWidth(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if (r < 'ァ' || r > 'ヺ') && r != 'ー' {
+			b.WriteRune(r)
+		} else if half, ok := fullToHalf[r]; ok {
+			b.WriteRune(half)
+		} else if pair, ok := halfWidthDecomposition[r]; ok {
+			b.WriteString(pair)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ConvertScript converts s to the named target: "hiragana", "katakana" or
+// "halfwidth"
+func ConvertScript(s string, target string) (string, bool) {
+	switch strings.ToLower(target) {
+	case string(ScriptHiragana):
+		return ToHiragana(s), true
+	case string(ScriptKatakana):
+		return ToKatakana(s), true
+	case "halfwidth", "half-width":
+		return ToHalfWidth(s), true
+	}
+	return "", false
+}
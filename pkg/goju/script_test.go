@@ -0,0 +1,65 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestScriptConversion(t *testing.T) {
+	tests := []struct {
+		name     string
+		convert  func(string) string
+		input    string
+		expected string
+	}{
+		{"Hiragana to katakana", ToKatakana, "ひらがな", "ヒラガナ"},
+		{"Small kana to katakana", ToKatakana, "きゃっと", "キャット"},
+		{"Iteration mark to katakana", ToKatakana, "いすゞ", "イスヾ"},
+		{"Mixed text to katakana", ToKatakana, "日本ごgo、です", "日本ゴgo、デス"},
+		{"Katakana unchanged", ToKatakana, "カタカナ", "カタカナ"},
+		{"Katakana to hiragana", ToHiragana, "カタカナ", "かたかな"},
+		{"Vu to hiragana", ToHiragana, "ヴ", "ゔ"},
+		{"Long vowel kept", ToHiragana, "ラーメン", "らーめん"},
+		{"No hiragana form kept", ToHiragana, "ヷ", "ヷ"},
+		{"Half-width katakana", ToHalfWidth, "カタカナ", "ｶﾀｶﾅ"},
+		{"Half-width voiced", ToHalfWidth, "ガンバ", "ｶﾞﾝﾊﾞ"},
+		{"Half-width handakuten", ToHalfWidth, "パーティー", "ﾊﾟｰﾃｨｰ"},
+		{"Half-width long vowel", ToHalfWidth, "カード", "ｶｰﾄﾞ"},
+		{"Half-width vu", ToHalfWidth, "ヴァ", "ｳﾞｧ"},
+		{"Half-width keeps punctuation", ToHalfWidth, "「ア」。", "「ｱ」。"},
+		{"Half-width keeps hiragana", ToHalfWidth, "かなカナ", "かなｶﾅ"},
+		{"Half-width round trip", func(s string) string { return Normalize(ToHalfWidth(s)) }, "ギュウドン", "ギュウドン"},
+		{"Half-width long vowel round trip", func(s string) string { return Normalize(ToHalfWidth(s)) }, "ラーメン", "ラーメン"},
+		{"Empty string", ToKatakana, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.convert(tt.input); got != tt.expected {
+				t.Errorf("convert(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScriptConversionCoversTable(t *testing.T) {
+	for _, char := range DefaultRegistry().All() {
+		if char.Hiragana == "" || char.Katakana == "" {
+			continue
+		}
+		if got := ToKatakana(char.Hiragana); got != char.Katakana {
+			t.Errorf("ToKatakana(%s) = %s, want %s", char.Hiragana, got, char.Katakana)
+		}
+		if got := ToHiragana(char.Katakana); got != char.Hiragana {
+			t.Errorf("ToHiragana(%s) = %s, want %s", char.Katakana, got, char.Hiragana)
+		}
+	}
+}
+
+func TestConvertScript(t *testing.T) {
+	if got, ok := ConvertScript("かな", "Katakana"); !ok || got != "カナ" {
+		t.Errorf("ConvertScript(katakana) = %q, %v", got, ok)
+	}
+	if _, ok := ConvertScript("かな", "kanji"); ok {
+		t.Error("ConvertScript(kanji) ok = true, want false")
+	}
+}
@@ -0,0 +1,119 @@
+package goju
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSegmentations caps how many parses Segment lists, since every bare
+// n before y doubles them
+const maxSegmentations = 64
+
+// RomajiUnit is the romaji for one kana unit of a longer romaji string.
+// Start and End are byte offsets into the normalized input.
+type RomajiUnit struct {
+	Text  string // romaji as written, such as "shi", or "k" for the っ of "kk"
+	Start int
+	End   int
+	Chars []Character // every character Text can stand for, most frequent first
+}
+
+// Segment splits romaji into kana units using the default registry,
+// see Registry.Segment
+func Segment(romaji string) [][]RomajiUnit {
+	return defaultRegistry.Segment(romaji)
+}
+
+// Segment splits romaji into kana units in every valid way, the parse with
+// the fewest units first. Spellings from any supported system are accepted,
+// along with:
+//   - a doubled consonant (kk, tch) for っ
+//   - n' for ん before a vowel; a bare n is ん only before a consonant or
+//     at the end, so "shinya" parses as both shi・nya and shi・n・ya
+//   - - for ー
+//
+// Romaji that cannot be split completely has no parses.
+func (r *Registry) Segment(romaji string) [][]RomajiUnit {
+	s := Normalize(strings.TrimSpace(romaji))
+	if s == "" {
+		return nil
+	}
+	longest := 0
+	for spelling := range r.byAnyRomaji {
+		longest = max(longest, len(spelling))
+	}
+
+	// from returns the parses of s[pos:], memoized so shared endings are
+	// only split once
+	memo := make(map[int][][]RomajiUnit)
+	var from func(pos int) [][]RomajiUnit
+	from = func(pos int) [][]RomajiUnit {
+		if pos == len(s) {
+			return [][]RomajiUnit{nil}
+		}
+		if parses, ok := memo[pos]; ok {
+			return parses
+		}
+		var parses [][]RomajiUnit
+		for _, unit := range r.romajiUnits(s, pos, longest) {
+			for _, rest := range from(unit.End) {
+				if len(parses) == maxSegmentations {
+					break
+				}
+				parses = append(parses, append([]RomajiUnit{unit}, rest...))
+			}
+		}
+		memo[pos] = parses
+		return parses
+	}
+
+	parses := from(0)
+	sort.SliceStable(parses, func(a, b int) bool {
+		return len(parses[a]) < len(parses[b])
+	})
+	return parses
+}
+
+// romajiUnits returns every unit that can start at pos in s, longest first
+func (r *Registry) romajiUnits(s string, pos, longest int) []RomajiUnit {
+	var units []RomajiUnit
+	add := func(end int, chars []Character) {
+		if len(chars) > 0 {
+			units = append(units, RomajiUnit{Text: s[pos:end], Start: pos, End: end, Chars: chars})
+		}
+	}
+	byKana := func(kana string) []Character {
+		if char, ok := r.ByHiragana(kana); ok {
+			return []Character{char}
+		}
+		return nil
+	}
+
+	rest := s[pos:]
+	switch {
+	case strings.HasPrefix(rest, "n'"):
+		add(pos+2, byKana("ん"))
+	case len(rest) > 1 && isConsonant(rest[0]) && rest[0] != 'n' && (rest[1] == rest[0] || strings.HasPrefix(rest, "tch")):
+		add(pos+1, byKana(sokuonHiragana))
+	}
+
+	for length := min(len(rest), longest); length > 0; length-- {
+		spelling := rest[:length]
+		// A bare n before a vowel starts na, ni and so on; ん there is n'
+		if spelling == "n" && len(rest) > 1 && (isVowel(rest[1]) || rest[1] == '\'') {
+			continue
+		}
+		add(pos+length, r.collect(r.byAnyRomaji[spelling]))
+	}
+	return units
+}
+
+// SegmentKana returns the kana of a parse, taking the most frequent
+// character for each unit
+func SegmentKana(units []RomajiUnit, script Script) string {
+	var sb strings.Builder
+	for _, unit := range units {
+		sb.WriteString(kana(unit.Chars[0], script))
+	}
+	return sb.String()
+}
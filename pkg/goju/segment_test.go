@@ -0,0 +1,68 @@
+package goju
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	tests := []struct {
+		romaji string
+		want   []string // each parse with units joined by ・
+	}{
+		{"aiu", []string{"a・i・u"}},
+		{"kanai", []string{"ka・na・i"}},
+		{"shinya", []string{"shi・nya", "shi・n・ya"}},
+		{"shin'ya", []string{"shi・n'・ya"}},
+		{"kitte", []string{"ki・t・te"}},
+		{"matcha", []string{"ma・t・cha"}},
+		{"konnichiha", []string{"ko・n・ni・chi・ha"}},
+		{"ra-men", []string{"ra・-・me・n"}},
+		{"sinbun", []string{"si・n・bu・n"}}, // Kunrei-shiki spelling
+		{"KA", []string{"ka"}},            // normalized to lower case
+		{"kq", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.romaji, func(t *testing.T) {
+			var got []string
+			for _, parse := range Segment(tt.romaji) {
+				texts := make([]string, len(parse))
+				for i, unit := range parse {
+					texts[i] = unit.Text
+				}
+				got = append(got, strings.Join(texts, "・"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Segment(%q) = %v, want %v", tt.romaji, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentUnits(t *testing.T) {
+	parses := Segment("kitte")
+	if len(parses) != 1 {
+		t.Fatalf("Segment(kitte) gave %d parses, want 1", len(parses))
+	}
+	if got := SegmentKana(parses[0], ScriptHiragana); got != "きって" {
+		t.Errorf("SegmentKana() = %q, want きって", got)
+	}
+	for _, unit := range parses[0] {
+		if "kitte"[unit.Start:unit.End] != unit.Text {
+			t.Errorf("unit %q has offsets %d-%d", unit.Text, unit.Start, unit.End)
+		}
+	}
+
+	parses = Segment("ji")
+	if len(parses) != 1 || len(parses[0][0].Chars) != 2 || parses[0][0].Chars[0].Hiragana != "じ" {
+		t.Errorf("Segment(ji) = %+v, want one unit for じ then ぢ", parses)
+	}
+
+	// Every bare n before y doubles the parses, up to the cap
+	if got := len(Segment(strings.Repeat("nya", 10))); got != maxSegmentations {
+		t.Errorf("Segment(nya...) gave %d parses, want %d", got, maxSegmentations)
+	}
+}
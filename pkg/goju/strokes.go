@@ -0,0 +1,302 @@
+package goju
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// StrokeBox is the size of the square that stroke coordinates lie in.
+// X grows to the right and Y grows downwards.
+const StrokeBox = 100
+
+// Point is a position inside the stroke box
+type Point struct {
+	X, Y int
+}
+
+// Stroke is one pen stroke, drawn as a polyline from its first point
+type Stroke []Point
+
+// kanaStrokePaths holds the strokes of each basic kana in writing order.
+// Each stroke is a list of "x,y" points; the shapes are simplified but the
+// number, order and direction of strokes follow standard handwriting.
+var kanaStrokePaths = map[string][]string{
+	// Hiragana
+	"あ": {"20,28 78,24", "45,10 45,40 48,70 52,88", "62,38 55,55 42,75 30,82 22,74 26,60 42,52 62,50 78,58 80,72 70,84 56,90"},
+	"い": {"25,25 22,50 25,72 32,80 38,72", "70,32 78,48 80,65"},
+	"う": {"40,12 60,18", "28,40 50,34 68,38 70,55 60,72 40,90"},
+	"え": {"40,12 60,18", "25,40 65,38 30,80 45,62 55,66 58,82 80,84"},
+	"お": {"18,30 60,28", "38,10 38,70 36,84 26,82 20,72 32,60 55,55 72,62 74,78 60,88", "72,20 84,32"},
+	"か": {"15,35 60,30 62,50 58,78 48,86", "35,12 30,45 18,85", "72,28 82,45 86,62"},
+	"き": {"22,25 70,20", "22,45 75,40", "40,8 70,70", "30,62 28,78 45,86 72,86"},
+	"く": {"65,12 30,50 65,88"},
+	"け": {"22,15 18,50 24,85", "40,35 85,32", "65,12 66,55 58,80 42,92"},
+	"こ": {"28,28 65,25 72,32", "25,70 40,80 78,78"},
+	"さ": {"20,35 80,28", "40,10 68,60", "28,68 30,82 50,88 70,86"},
+	"し": {"35,10 33,65 40,85 55,88 75,70"},
+	"す": {"15,30 85,28", "52,10 52,55 40,62 38,50 52,48 55,62 48,90"},
+	"せ": {"12,42 88,38", "68,15 68,58 60,62", "32,15 30,70 40,84 75,86"},
+	"そ": {"30,18 62,14 28,40 80,36 50,50 44,65 50,82 65,88"},
+	"た": {"15,30 50,28", "35,10 20,88", "55,48 82,46", "55,72 60,84 85,84"},
+	"ち": {"18,30 75,26", "42,10 32,60 50,52 70,55 74,70 64,84 40,90"},
+	"つ": {"12,40 50,30 80,38 84,55 70,72 45,84"},
+	"て": {"12,25 82,20 50,35 38,55 45,80 62,88"},
+	"と": {"35,15 42,45", "70,32 35,55 30,72 42,84 75,86"},
+	"な": {"15,30 52,26", "35,10 20,62", "72,38 82,52", "58,45 60,82 48,90 38,82 48,72 65,76 85,90"},
+	"に": {"22,15 18,50 24,85", "45,30 78,28", "45,62 50,78 82,80"},
+	"ぬ": {"22,25 35,80", "60,15 35,70 22,82 15,70 40,45 62,40 78,52 80,72 65,86 55,80 62,72 78,78 88,88"},
+	"ね": {"28,10 30,90", "12,35 40,30 18,75 42,45 62,38 78,50 80,72 68,86 58,80 66,72 80,78 90,88"},
+	"の": {"50,30 38,72 25,78 18,62 30,40 55,30 78,40 85,60 75,80 55,88"},
+	"は": {"18,15 15,50 20,85", "40,35 82,32", "62,12 64,70 55,84 44,78 50,68 65,72 85,86"},
+	"ひ": {"15,30 40,26 28,55 30,80 50,86 68,70 70,40 78,62 90,70"},
+	"ふ": {"42,12 58,24", "50,34 40,58 52,70 48,85 38,84", "22,55 12,75", "75,55 88,72"},
+	"へ": {"12,58 32,35 88,72"},
+	"ほ": {"18,15 15,50 20,85", "40,20 80,18", "40,45 80,42", "60,18 62,70 55,84 44,78 50,68 65,72 85,86"},
+	"ま": {"20,25 80,22", "20,48 80,45", "50,10 52,70 45,84 34,78 40,68 56,72 76,86"},
+	"み": {"25,20 50,18 30,68 20,76 14,68 30,55 58,60 80,75", "72,40 60,80 48,90"},
+	"む": {"12,32 55,30", "35,12 33,58 22,58 24,48 35,55 32,80 45,88 70,86 75,70", "72,25 82,38"},
+	"め": {"25,25 35,78", "60,15 35,70 22,82 15,70 40,45 62,40 78,52 80,72 65,86 50,90"},
+	"も": {"40,10 32,60 38,82 55,88 70,76 72,55", "18,35 60,32", "18,55 60,52"},
+	"や": {"18,40 50,28 80,32 82,48 70,58 58,56", "45,14 55,26", "30,18 55,88"},
+	"ゆ": {"20,22 18,70 30,60 55,45 75,48 80,65 65,80 50,72 40,60", "55,12 60,65 50,90"},
+	"よ": {"52,38 78,35", "50,12 50,68 40,80 26,82 22,72 34,64 55,66 80,85"},
+	"ら": {"35,10 48,20", "25,30 22,68 42,55 65,58 72,72 62,85 40,90"},
+	"り": {"28,18 25,55 32,62", "65,12 70,45 62,75 45,90"},
+	"る": {"25,22 68,18 25,68 50,52 72,56 78,72 65,86 48,86 44,76 55,72 62,80"},
+	"れ": {"28,10 30,90", "12,35 40,30 18,75 45,42 62,38 66,72 72,82 88,80"},
+	"ろ": {"25,22 68,18 25,68 50,52 72,56 78,72 65,86 40,88"},
+	"わ": {"28,10 30,90", "12,35 40,30 18,75 45,42 62,38 80,48 82,66 70,80 55,86"},
+	"を": {"18,28 62,24", "42,10 22,50 48,40 58,46 55,60", "75,42 40,62 36,76 48,86 78,86"},
+	"ん": {"55,10 18,88 40,52 55,50 58,75 65,85 82,68"},
+	"ゝ": {"38,30 62,60 35,85"},
+
+	// Katakana
+	"ア": {"15,20 82,18 72,40 58,48", "50,30 48,60 25,88"},
+	"イ": {"65,12 40,45 15,62", "48,38 48,90"},
+	"ウ": {"50,8 50,22", "22,25 22,45", "22,28 78,28 75,55 45,88"},
+	"エ": {"22,22 78,22", "50,22 50,80", "12,82 88,82"},
+	"オ": {"12,35 88,35", "60,10 60,88 52,84", "55,40 15,80"},
+	"カ": {"18,35 75,32 72,70 62,86 52,80", "45,10 40,60 18,88"},
+	"キ": {"15,32 80,26", "18,58 85,52", "45,10 55,90"},
+	"ク": {"40,10 15,45", "32,25 78,25 65,60 30,90"},
+	"ケ": {"35,10 12,48", "26,32 85,32", "60,32 58,60 35,90"},
+	"コ": {"20,25 78,25 78,78", "20,78 78,78"},
+	"サ": {"12,35 88,35", "32,12 32,58", "68,12 66,60 40,90"},
+	"シ": {"18,20 35,30", "12,45 30,55", "18,88 55,70 85,25"},
+	"ス": {"18,22 75,22 55,55 18,88", "52,58 85,88"},
+	"セ": {"10,45 82,35 60,58", "32,12 32,78 42,86 78,86"},
+	"ソ": {"20,25 35,50", "80,18 62,58 25,90"},
+	"タ": {"40,10 15,45", "32,25 78,25 65,60 30,90", "30,48 68,62"},
+	"チ": {"70,10 25,22", "12,45 88,45", "48,22 48,65 30,90"},
+	"ツ": {"15,25 28,45", "42,20 52,40", "85,22 65,65 30,90"},
+	"テ": {"25,18 75,18", "12,42 88,42", "50,42 48,68 30,90"},
+	"ト": {"38,10 38,90", "40,40 75,58"},
+	"ナ": {"12,38 88,38", "52,10 50,62 30,90"},
+	"ニ": {"25,28 75,28", "12,75 88,75"},
+	"ヌ": {"20,22 78,22 60,60 20,88", "35,45 80,85"},
+	"ネ": {"48,8 52,22", "18,28 78,28 15,78", "48,52 48,92", "62,55 85,75"},
+	"ノ": {"72,12 60,55 20,90"},
+	"ハ": {"35,25 12,78", "60,22 88,78"},
+	"ヒ": {"25,48 70,30", "25,12 25,78 35,86 80,86"},
+	"フ": {"15,22 82,22 70,58 30,90"},
+	"ヘ": {"10,60 35,30 90,78"},
+	"ホ": {"15,32 85,32", "50,10 50,85 42,80", "30,50 12,78", "70,50 88,75"},
+	"マ": {"12,25 85,25 70,48 45,70", "35,45 68,82"},
+	"ミ": {"28,18 70,30", "32,45 68,55", "22,70 75,88"},
+	"ム": {"50,12 15,78 82,70", "65,50 85,88"},
+	"メ": {"75,12 60,52 18,88", "28,35 82,80"},
+	"モ": {"22,22 78,22", "12,48 88,48", "45,22 45,78 55,86 85,86"},
+	"ヤ": {"12,42 82,30 70,52", "35,12 52,90"},
+	"ユ": {"20,35 70,35 70,80", "10,80 90,80"},
+	"ヨ": {"22,20 78,20 78,85", "26,52 78,52", "22,85 78,85"},
+	"ラ": {"25,18 75,18", "18,42 82,42 68,72 35,90"},
+	"リ": {"30,18 30,62", "72,12 72,55 40,90"},
+	"ル": {"35,15 35,55 15,88", "58,12 58,80 88,55"},
+	"レ": {"30,12 30,85 85,50"},
+	"ロ": {"22,22 22,80", "22,22 78,22 78,80", "22,78 78,78"},
+	"ワ": {"20,20 20,45", "20,22 80,22 72,60 35,90"},
+	"ヲ": {"18,22 80,22", "20,50 76,50", "80,22 72,60 35,90"},
+	"ン": {"18,25 35,40", "20,88 55,70 85,25"},
+	"ヽ": {"35,30 65,65"},
+	"ー": {"10,50 90,50"},
+}
+
+// Marks added to the top right of a kana to voice it
+var (
+	dakutenStrokes    = mustParseStrokes([]string{"78,6 84,20", "88,2 94,16"})
+	handakutenStrokes = mustParseStrokes([]string{"88,4 93,6 95,11 93,16 88,18 83,16 81,11 83,6 88,4"})
+)
+
+// kanaStrokes is kanaStrokePaths parsed into points
+var kanaStrokes = parseStrokeTable(kanaStrokePaths)
+
+// parseStrokes parses the "x,y x,y" form used in kanaStrokePaths
+func parseStrokes(paths []string) ([]Stroke, error) {
+	strokes := make([]Stroke, len(paths))
+	for i, path := range paths {
+		for _, pair := range strings.Fields(path) {
+			x, y, ok := strings.Cut(pair, ",")
+			if !ok {
+				return nil, fmt.Errorf("stroke %d: point %q has no comma", i+1, pair)
+			}
+			px, errX := strconv.Atoi(x)
+			py, errY := strconv.Atoi(y)
+			if errX != nil || errY != nil {
+				return nil, fmt.Errorf("stroke %d: invalid point %q", i+1, pair)
+			}
+			if px < 0 || px > StrokeBox || py < 0 || py > StrokeBox {
+				return nil, fmt.Errorf("stroke %d: point %q is outside the stroke box", i+1, pair)
+			}
+			strokes[i] = append(strokes[i], Point{X: px, Y: py})
+		}
+		if len(strokes[i]) == 0 {
+			return nil, fmt.Errorf("stroke %d is empty", i+1)
+		}
+	}
+	return strokes, nil
+}
+
+func mustParseStrokes(paths []string) []Stroke {
+	strokes, err := parseStrokes(paths)
+	if err != nil {
+		panic(err)
+	}
+	return strokes
+}
+
+func parseStrokeTable(table map[string][]string) map[string][]Stroke {
+	parsed := make(map[string][]Stroke, len(table))
+	for kana, paths := range table {
+		strokes, err := parseStrokes(paths)
+		if err != nil {
+			panic(fmt.Sprintf("strokes for %s: %v", kana, err))
+		}
+		parsed[kana] = strokes
+	}
+	return parsed
+}
+
+// scaleStrokes shrinks strokes towards the bottom left, as small kana are
+// written
+func scaleStrokes(strokes []Stroke) []Stroke {
+	scaled := make([]Stroke, len(strokes))
+	for i, stroke := range strokes {
+		scaled[i] = make(Stroke, len(stroke))
+		for j, p := range stroke {
+			scaled[i][j] = Point{X: 15 + p.X*65/100, Y: 30 + p.Y*65/100}
+		}
+	}
+	return scaled
+}
+
+// GetStrokes returns the strokes of a single kana in writing order. Voiced
+// kana are drawn as their base followed by the dakuten or handakuten, and
+// small kana as a smaller copy of the full-size kana.
+func GetStrokes(kana string) ([]Stroke, bool) {
+	if utf8.RuneCountInString(kana) != 1 {
+		return nil, false
+	}
+	if strokes, ok := kanaStrokes[kana]; ok {
+		return strokes, true
+	}
+
+	r, _ := utf8.DecodeRuneInString(kana)
+	for base, composed := range voicedComposition {
+		if composed == r {
+			if strokes, ok := kanaStrokes[string(base)]; ok {
+				return append(append([]Stroke(nil), strokes...), dakutenStrokes...), true
+			}
+		}
+	}
+	for base, composed := range semiVoicedComposition {
+		if composed == r {
+			if strokes, ok := kanaStrokes[string(base)]; ok {
+				return append(append([]Stroke(nil), strokes...), handakutenStrokes...), true
+			}
+		}
+	}
+	// Small kana sit one code point before their full-size form in both scripts
+	if strings.Contains(smallKana, ToHiragana(kana)) {
+		if strokes, ok := kanaStrokes[string(r+1)]; ok {
+			return scaleStrokes(strokes), true
+		}
+	}
+	return nil, false
+}
+
+// Strokes returns the strokes of the character's kana in the given script.
+// Characters written with more than one kana, such as yoon, have none.
+func (c Character) Strokes(script Script) ([]Stroke, bool) {
+	return GetStrokes(kana(c, script))
+}
+
+// StrokeStepper steps through strokes one segment at a time, for drawing
+// them progressively
+type StrokeStepper struct {
+	strokes []Stroke
+	stroke  int // index of the stroke being drawn
+	points  int // points of that stroke drawn so far
+}
+
+// NewStrokeStepper creates a stepper with nothing drawn yet
+func NewStrokeStepper(strokes []Stroke) *StrokeStepper {
+	return &StrokeStepper{strokes: strokes}
+}
+
+// Next draws one more segment and reports whether anything was added
+func (s *StrokeStepper) Next() bool {
+	if s.Done() {
+		return false
+	}
+	if s.points == len(s.strokes[s.stroke]) {
+		s.stroke++
+		s.points = 0
+	}
+	s.points++
+	// The first step of a stroke draws its first segment, not a lone point
+	if s.points == 1 && len(s.strokes[s.stroke]) > 1 {
+		s.points = 2
+	}
+	return true
+}
+
+// Done reports whether every stroke has been drawn completely
+func (s *StrokeStepper) Done() bool {
+	if len(s.strokes) == 0 {
+		return true
+	}
+	last := len(s.strokes) - 1
+	return s.stroke == last && s.points == len(s.strokes[last])
+}
+
+// Stroke returns the number of the stroke being drawn, starting at 1, or
+// 0 before the first step
+func (s *StrokeStepper) Stroke() int {
+	if s.points == 0 {
+		return 0
+	}
+	return s.stroke + 1
+}
+
+// Total returns the number of strokes
+func (s *StrokeStepper) Total() int {
+	return len(s.strokes)
+}
+
+// Drawn returns the strokes drawn so far, the last one possibly partial
+func (s *StrokeStepper) Drawn() []Stroke {
+	if s.points == 0 {
+		return nil
+	}
+	drawn := make([]Stroke, 0, s.stroke+1)
+	drawn = append(drawn, s.strokes[:s.stroke]...)
+	return append(drawn, s.strokes[s.stroke][:s.points])
+}
+
+// Reset clears the drawing so stepping starts again from the first stroke
+func (s *StrokeStepper) Reset() {
+	s.stroke = 0
+	s.points = 0
+}
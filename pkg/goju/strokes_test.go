@@ -0,0 +1,121 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestGetStrokes(t *testing.T) {
+	tests := []struct {
+		kana      string
+		wantCount int
+		wantFound bool
+	}{
+		{"あ", 3, true},
+		{"き", 4, true},
+		{"く", 1, true},
+		{"ア", 2, true},
+		{"ヲ", 3, true},
+		{"ネ", 4, true},
+		{"が", 5, true}, // か plus dakuten
+		{"ぽ", 5, true}, // ほ plus handakuten
+		{"ヴ", 5, true},
+		{"っ", 1, true},
+		{"ャ", 2, true},
+		{"きゃ", 0, false},
+		{"x", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			strokes, found := GetStrokes(tt.kana)
+			if found != tt.wantFound || len(strokes) != tt.wantCount {
+				t.Errorf("GetStrokes(%s) = %d strokes, %v, want %d, %v", tt.kana, len(strokes), found, tt.wantCount, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestEveryBasicKanaHasStrokes(t *testing.T) {
+	for _, category := range []Category{Seion, Dakuon, Handaku} {
+		for _, char := range GetCharactersByCategory(category) {
+			for _, script := range []Script{ScriptHiragana, ScriptKatakana} {
+				if _, ok := char.Strokes(script); !ok {
+					t.Errorf("%s has no %s strokes", char.ID, script)
+				}
+			}
+		}
+	}
+}
+
+func TestSmallKanaStrokesAreSmaller(t *testing.T) {
+	small, _ := GetStrokes("っ")
+	for _, p := range small[0] {
+		// Small kana are drawn in the lower left two thirds of the box
+		if p.X > 80 || p.Y < 30 {
+			t.Errorf("point %v of っ lies outside the small kana area", p)
+		}
+	}
+}
+
+func TestStrokeStepper(t *testing.T) {
+	strokes := []Stroke{
+		{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 20, Y: 0}},
+		{{X: 0, Y: 10}, {X: 10, Y: 10}},
+	}
+	stepper := NewStrokeStepper(strokes)
+	if stepper.Stroke() != 0 || stepper.Drawn() != nil {
+		t.Fatalf("new stepper has drawn %v", stepper.Drawn())
+	}
+
+	// Each step draws one segment: two for the first stroke, one for the second
+	wantStrokes := []int{1, 1, 2}
+	wantPoints := [][]int{{2}, {3}, {3, 2}}
+	for step := range wantStrokes {
+		if !stepper.Next() {
+			t.Fatalf("Next() step %d = false", step+1)
+		}
+		if stepper.Stroke() != wantStrokes[step] {
+			t.Errorf("step %d Stroke() = %v, want %v", step+1, stepper.Stroke(), wantStrokes[step])
+		}
+		drawn := stepper.Drawn()
+		if len(drawn) != len(wantPoints[step]) {
+			t.Fatalf("step %d Drawn() = %v", step+1, drawn)
+		}
+		for i, stroke := range drawn {
+			if len(stroke) != wantPoints[step][i] {
+				t.Errorf("step %d stroke %d has %d points, want %d", step+1, i+1, len(stroke), wantPoints[step][i])
+			}
+		}
+	}
+
+	if !stepper.Done() || stepper.Next() {
+		t.Error("stepper not done after drawing every segment")
+	}
+	stepper.Reset()
+	if stepper.Done() || stepper.Drawn() != nil {
+		t.Error("Reset() did not clear the drawing")
+	}
+}
+
+func TestStrokeDirection(t *testing.T) {
+	tests := []struct {
+		kana      string
+		stroke    int
+		wantRight bool // whether the stroke ends to the right of where it starts
+	}{
+		{"ヒ", 1, true},  // rising tick, left to right
+		{"チ", 1, false}, // falling sweep, right to left
+		{"ニ", 1, true},
+		{"ノ", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			strokes, _ := GetStrokes(tt.kana)
+			stroke := strokes[tt.stroke-1]
+			if right := stroke[len(stroke)-1].X > stroke[0].X; right != tt.wantRight {
+				t.Errorf("stroke %d of %s runs right = %v, want %v", tt.stroke, tt.kana, right, tt.wantRight)
+			}
+		})
+	}
+}
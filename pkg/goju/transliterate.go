@@ -0,0 +1,238 @@
+package goju
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Run represents a span of the input that could not be transliterated.
+// Start and End are byte offsets into the original string.
+type Run struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// TransliterationError reports every run of input that could not be converted
+type TransliterationError struct {
+	Runs []Run
+}
+
+// Error implements the error interface
+func (e *TransliterationError) Error() string {
+	texts := make([]string, len(e.Runs))
+	for i, run := range e.Runs {
+		texts[i] = fmt.Sprintf("%q at byte %d", run.Text, run.Start)
+	}
+	return fmt.Sprintf("cannot transliterate %s", strings.Join(texts, ", "))
+}
+
+// tokenKind classifies a token produced by tokenize
+type tokenKind int
+
+const (
+	tokenKana    tokenKind = iota // a table entry such as か or きゃ
+	tokenSokuon                   // っ or ッ
+	tokenChoonpu                  // ー
+	tokenUnknown                  // anything not in the table
+)
+
+// token is a single unit of a kana string with its byte span
+type token struct {
+	Kind  tokenKind
+	Text  string
+	Start int
+	End   int
+	Char  Character
+}
+
+const (
+	sokuonHiragana = "っ"
+	sokuonKatakana = "ッ"
+	choonpu        = "ー"
+
+	iterationMarkID       = "modifier-iteration"
+	voicedIterationMarkID = "modifier-voiced-iteration"
+)
+
+// tokenize splits a kana string into entries of the default registry
+func tokenize(s string) []token {
+	return defaultRegistry.tokenize(s)
+}
+
+// tokenize splits a kana string into table entries using longest match.
+// Consecutive unmatched runes are merged into a single unknown token.
+func (r *Registry) tokenize(s string) []token {
+	runes := []rune(s)
+	offsets := make([]int, len(runes)+1)
+	for i, pos := 0, 0; i < len(runes); i++ {
+		offsets[i] = pos
+		pos += utf8.RuneLen(runes[i])
+		offsets[i+1] = pos
+	}
+
+	longest := r.MaxKanaLength()
+	var tokens []token
+	for i := 0; i < len(runes); {
+		matched := false
+		for n := longest; n > 0; n-- {
+			if i+n > len(runes) {
+				continue
+			}
+			text := string(runes[i : i+n])
+			tok := token{Text: text, Start: offsets[i], End: offsets[i+n]}
+			switch {
+			case text == sokuonHiragana || text == sokuonKatakana:
+				tok.Kind = tokenSokuon
+			case text == choonpu:
+				tok.Kind = tokenChoonpu
+			default:
+				char, ok := r.ByKana(text)
+				if !ok {
+					continue
+				}
+				tok.Kind = tokenKana
+				tok.Char = char
+			}
+			tokens = append(tokens, tok)
+			i += n
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		if last := len(tokens) - 1; last >= 0 && tokens[last].Kind == tokenUnknown {
+			tokens[last].Text += string(runes[i])
+			tokens[last].End = offsets[i+1]
+		} else {
+			tokens = append(tokens, token{
+				Kind:  tokenUnknown,
+				Text:  string(runes[i]),
+				Start: offsets[i],
+				End:   offsets[i+1],
+			})
+		}
+		i++
+	}
+	return tokens
+}
+
+// voicedForm returns the dakuten form of a single kana, such as が for か.
+// A kana that already has dakuten is its own voiced form.
+func voicedForm(char Character) (Character, bool) {
+	if voiced, ok := VoicedOf(char); ok {
+		return voiced, true
+	}
+	if char.Base != "" && Voice(char.Hiragana) == char.Hiragana {
+		return char, true
+	}
+	return Character{}, false
+}
+
+// isVowel reports whether b is a romaji vowel
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// geminate returns the consonant that a preceding sokuon doubles in romaji,
+// or false if the romaji does not start with a consonant
+func geminate(romaji string) (string, bool) {
+	if romaji == "" || isVowel(romaji[0]) || romaji == "n" {
+		return "", false
+	}
+	if strings.HasPrefix(romaji, "ch") {
+		return "t", true
+	}
+	return romaji[:1], true
+}
+
+// Transliterate converts a kana string to Hepburn romaji.
+// Yoon are matched before their component kana, a sokuon doubles the
+// consonant that follows it, ー repeats the preceding vowel, and ん is
+// written n' before a vowel or y. The iteration marks ゝ and ゞ repeat the
+// preceding kana, the latter with dakuten. Input that cannot be converted is copied
+// to the output unchanged and reported in a *TransliterationError.
+func Transliterate(kana string) (string, error) {
+	return TransliterateIn(kana, Hepburn)
+}
+
+// TransliterateIn converts a kana string to romaji in the given system,
+// following the same rules as Transliterate
+func TransliterateIn(kana string, system RomanizationSystem) (string, error) {
+	tokens := tokenize(kana)
+
+	var sb strings.Builder
+	var runs []Run
+	unconvertible := func(tok token) {
+		sb.WriteString(tok.Text)
+		if last := len(runs) - 1; last >= 0 && runs[last].End == tok.Start {
+			runs[last].Text += tok.Text
+			runs[last].End = tok.End
+			return
+		}
+		runs = append(runs, Run{Text: tok.Text, Start: tok.Start, End: tok.End})
+	}
+
+	var previous *Character
+	for i, tok := range tokens {
+		switch tok.Kind {
+		case tokenKana:
+			switch tok.Char.ID {
+			case iterationMarkID, voicedIterationMarkID:
+				if previous == nil {
+					unconvertible(tok)
+					continue
+				}
+				repeated := *previous
+				if tok.Char.ID == voicedIterationMarkID {
+					voiced, ok := voicedForm(repeated)
+					if !ok {
+						unconvertible(tok)
+						continue
+					}
+					repeated = voiced
+				}
+				sb.WriteString(repeated.RomajiIn(system))
+				continue
+			}
+			if tok.Char.Category != Modifier {
+				previous = &tokens[i].Char
+			}
+
+			romaji := tok.Char.RomajiIn(system)
+			if romaji == "n" && i+1 < len(tokens) && tokens[i+1].Kind == tokenKana {
+				next := tokens[i+1].Char.RomajiIn(system)
+				if next != "" && (isVowel(next[0]) || next[0] == 'y') {
+					romaji = "n'"
+				}
+			}
+			sb.WriteString(romaji)
+		case tokenSokuon:
+			if i+1 < len(tokens) && tokens[i+1].Kind == tokenKana {
+				if consonant, ok := geminate(tokens[i+1].Char.RomajiIn(system)); ok {
+					sb.WriteString(consonant)
+					continue
+				}
+			}
+			unconvertible(tok)
+		case tokenChoonpu:
+			out := sb.String()
+			if i > 0 && tokens[i-1].Kind != tokenUnknown && out != "" && isVowel(out[len(out)-1]) {
+				sb.WriteByte(out[len(out)-1])
+				continue
+			}
+			unconvertible(tok)
+		default:
+			previous = nil
+			unconvertible(tok)
+		}
+	}
+
+	if len(runs) > 0 {
+		return sb.String(), &TransliterationError{Runs: runs}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,74 @@
+package goju
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"Single kana", "あ", "a", false},
+		{"Word", "ひらがな", "hiragana", false},
+		{"Yoon longest match", "きょうと", "kyouto", false},
+		{"Sokuon", "がっこう", "gakkou", false},
+		{"Sokuon before ch", "まっちゃ", "matcha", false},
+		{"Katakana sokuon", "ロボット", "robotto", false},
+		{"Choonpu", "ラーメン", "raamen", false},
+		{"N before vowel", "きんえん", "kin'en", false},
+		{"N before y", "こんや", "kon'ya", false},
+		{"N before consonant", "さんぽ", "sanpo", false},
+		{"Mixed scripts", "カタかな", "katakana", false},
+		{"Loanword fa", "ファイル", "fairu", false},
+		{"Loanword ti", "パーティー", "paatii", false},
+		{"Loanword v", "ヴァイオリン", "vaiorin", false},
+		{"Loanword che", "チェック", "chekku", false},
+		{"Iteration mark", "こゝろ", "kokoro", false},
+		{"Voiced iteration mark", "みすゞ", "misuzu", false},
+		{"Katakana iteration mark", "バナヽ", "banana", false},
+		{"Small kana on its own", "ぁ", "xa", false},
+		{"Empty string", "", "", false},
+		{"Unconvertible run", "あabcい", "aabci", true},
+		{"Trailing sokuon", "あっ", "aっ", true},
+		{"Leading choonpu", "ーあ", "ーa", true},
+		{"Leading iteration mark", "ゝあ", "ゝa", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Transliterate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transliterate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("Transliterate() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTransliterateRuns(t *testing.T) {
+	_, err := Transliterate("かabcき漢字")
+	var terr *TransliterationError
+	if !errors.As(err, &terr) {
+		t.Fatalf("Transliterate() error = %v, want *TransliterationError", err)
+	}
+
+	want := []Run{
+		{Text: "abc", Start: 3, End: 6},
+		{Text: "漢字", Start: 9, End: 15},
+	}
+	if len(terr.Runs) != len(want) {
+		t.Fatalf("Transliterate() runs = %v, want %v", terr.Runs, want)
+	}
+	for i, run := range terr.Runs {
+		if run != want[i] {
+			t.Errorf("Transliterate() run[%d] = %v, want %v", i, run, want[i])
+		}
+	}
+}
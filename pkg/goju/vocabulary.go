@@ -0,0 +1,253 @@
+package goju
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Word is an example word used to show kana in context
+type Word struct {
+	Kana     string            `yaml:"kana" json:"kana"`
+	Kanji    string            `yaml:"kanji,omitempty" json:"kanji,omitempty"`
+	Romaji   string            `yaml:"romaji,omitempty" json:"romaji,omitempty"` // Hepburn, derived from Kana when empty
+	Meanings map[string]string `yaml:"meanings" json:"meanings"`                 // translations keyed by language code
+}
+
+// RomajiIn returns the reading of the word in the given romanization system
+func (w Word) RomajiIn(system RomanizationSystem) string {
+	if system == Hepburn && w.Romaji != "" {
+		return w.Romaji
+	}
+	romaji, _ := TransliterateIn(w.Kana, system)
+	return romaji
+}
+
+// Meaning returns the translation in the given language or its nearest
+// fallback
+func (w Word) Meaning(language string) string {
+	return localized(w.Meanings, language)
+}
+
+// Format writes the word with its reading in the given romanization system
+// and its meaning in the given language, if it has one, such as
+// "ねこ 猫 (neko) cat"
+func (w Word) Format(system RomanizationSystem, language string) string {
+	written := w.Kana
+	if w.Kanji != "" {
+		written += " " + w.Kanji
+	}
+	formatted := fmt.Sprintf("%s (%s)", written, w.RomajiIn(system))
+	if meaning := w.Meaning(language); meaning != "" {
+		formatted += " " + meaning
+	}
+	return formatted
+}
+
+// meanings builds a translation map from English, Simplified and
+// Traditional Chinese text
+func meanings(en, zh, zhTW string) map[string]string {
+	return map[string]string{"en": en, "zh": zh, "zh-tw": zhTW}
+}
+
+// Words contains the built-in example words. Every basic kana except を,
+// which is only used as a particle, and ん starts at least one of them.
+var Words = []Word{
+	{Kana: "あさ", Kanji: "朝", Meanings: meanings("morning", "早晨", "早晨")},
+	{Kana: "あめ", Kanji: "雨", Meanings: meanings("rain", "雨", "雨")},
+	{Kana: "いぬ", Kanji: "犬", Meanings: meanings("dog", "狗", "狗")},
+	{Kana: "いえ", Kanji: "家", Meanings: meanings("house", "房子", "房子")},
+	{Kana: "うみ", Kanji: "海", Meanings: meanings("sea", "海", "海")},
+	{Kana: "うた", Kanji: "歌", Meanings: meanings("song", "歌", "歌")},
+	{Kana: "えき", Kanji: "駅", Meanings: meanings("station", "车站", "車站")},
+	{Kana: "えんぴつ", Kanji: "鉛筆", Meanings: meanings("pencil", "铅笔", "鉛筆")},
+	{Kana: "おかね", Kanji: "お金", Meanings: meanings("money", "钱", "錢")},
+	{Kana: "おちゃ", Kanji: "お茶", Meanings: meanings("tea", "茶", "茶")},
+	{Kana: "かさ", Kanji: "傘", Meanings: meanings("umbrella", "伞", "傘")},
+	{Kana: "かお", Kanji: "顔", Meanings: meanings("face", "脸", "臉")},
+	{Kana: "きた", Kanji: "北", Meanings: meanings("north", "北", "北")},
+	{Kana: "きっぷ", Kanji: "切符", Meanings: meanings("ticket", "票", "票")},
+	{Kana: "くち", Kanji: "口", Meanings: meanings("mouth", "嘴", "嘴")},
+	{Kana: "くるま", Kanji: "車", Meanings: meanings("car", "汽车", "汽車")},
+	{Kana: "けさ", Kanji: "今朝", Meanings: meanings("this morning", "今天早上", "今天早上")},
+	{Kana: "こども", Kanji: "子供", Meanings: meanings("child", "孩子", "孩子")},
+	{Kana: "ここ", Meanings: meanings("here", "这里", "這裡")},
+	{Kana: "さかな", Kanji: "魚", Meanings: meanings("fish", "鱼", "魚")},
+	{Kana: "さくら", Kanji: "桜", Meanings: meanings("cherry blossom", "樱花", "櫻花")},
+	{Kana: "しお", Kanji: "塩", Meanings: meanings("salt", "盐", "鹽")},
+	{Kana: "しんぶん", Kanji: "新聞", Meanings: meanings("newspaper", "报纸", "報紙")},
+	{Kana: "すし", Kanji: "寿司", Meanings: meanings("sushi", "寿司", "壽司")},
+	{Kana: "すいか", Kanji: "西瓜", Meanings: meanings("watermelon", "西瓜", "西瓜")},
+	{Kana: "せんせい", Kanji: "先生", Meanings: meanings("teacher", "老师", "老師")},
+	{Kana: "そら", Kanji: "空", Meanings: meanings("sky", "天空", "天空")},
+	{Kana: "たまご", Kanji: "卵", Meanings: meanings("egg", "鸡蛋", "雞蛋")},
+	{Kana: "ちず", Kanji: "地図", Meanings: meanings("map", "地图", "地圖")},
+	{Kana: "つき", Kanji: "月", Meanings: meanings("moon", "月亮", "月亮")},
+	{Kana: "て", Kanji: "手", Meanings: meanings("hand", "手", "手")},
+	{Kana: "とり", Kanji: "鳥", Meanings: meanings("bird", "鸟", "鳥")},
+	{Kana: "なつ", Kanji: "夏", Meanings: meanings("summer", "夏天", "夏天")},
+	{Kana: "にく", Kanji: "肉", Meanings: meanings("meat", "肉", "肉")},
+	{Kana: "ぬの", Kanji: "布", Meanings: meanings("cloth", "布", "布")},
+	{Kana: "ねこ", Kanji: "猫", Meanings: meanings("cat", "猫", "貓")},
+	{Kana: "のり", Kanji: "海苔", Meanings: meanings("seaweed", "海苔", "海苔")},
+	{Kana: "はな", Kanji: "花", Meanings: meanings("flower", "花", "花")},
+	{Kana: "ひと", Kanji: "人", Meanings: meanings("person", "人", "人")},
+	{Kana: "ふね", Kanji: "船", Meanings: meanings("ship", "船", "船")},
+	{Kana: "へや", Kanji: "部屋", Meanings: meanings("room", "房间", "房間")},
+	{Kana: "ほし", Kanji: "星", Meanings: meanings("star", "星星", "星星")},
+	{Kana: "ほん", Kanji: "本", Meanings: meanings("book", "书", "書")},
+	{Kana: "まど", Kanji: "窓", Meanings: meanings("window", "窗户", "窗戶")},
+	{Kana: "みず", Kanji: "水", Meanings: meanings("water", "水", "水")},
+	{Kana: "むし", Kanji: "虫", Meanings: meanings("insect", "虫子", "蟲子")},
+	{Kana: "め", Kanji: "目", Meanings: meanings("eye", "眼睛", "眼睛")},
+	{Kana: "もり", Kanji: "森", Meanings: meanings("forest", "森林", "森林")},
+	{Kana: "やま", Kanji: "山", Meanings: meanings("mountain", "山", "山")},
+	{Kana: "ゆき", Kanji: "雪", Meanings: meanings("snow", "雪", "雪")},
+	{Kana: "よる", Kanji: "夜", Meanings: meanings("night", "晚上", "晚上")},
+	{Kana: "らいねん", Kanji: "来年", Meanings: meanings("next year", "明年", "明年")},
+	{Kana: "りんご", Kanji: "林檎", Meanings: meanings("apple", "苹果", "蘋果")},
+	{Kana: "るす", Kanji: "留守", Meanings: meanings("being away from home", "不在家", "不在家")},
+	{Kana: "れきし", Kanji: "歴史", Meanings: meanings("history", "历史", "歷史")},
+	{Kana: "ろうそく", Kanji: "蝋燭", Meanings: meanings("candle", "蜡烛", "蠟燭")},
+	{Kana: "わたし", Kanji: "私", Meanings: meanings("I, me", "我", "我")},
+	{Kana: "がっこう", Kanji: "学校", Meanings: meanings("school", "学校", "學校")},
+	{Kana: "ぎんこう", Kanji: "銀行", Meanings: meanings("bank", "银行", "銀行")},
+	{Kana: "ごはん", Kanji: "ご飯", Meanings: meanings("rice, meal", "米饭", "米飯")},
+	{Kana: "ざっし", Kanji: "雑誌", Meanings: meanings("magazine", "杂志", "雜誌")},
+	{Kana: "でんしゃ", Kanji: "電車", Meanings: meanings("train", "电车", "電車")},
+	{Kana: "ぶた", Kanji: "豚", Meanings: meanings("pig", "猪", "豬")},
+	{Kana: "きょう", Kanji: "今日", Meanings: meanings("today", "今天", "今天")},
+	{Kana: "しゃしん", Kanji: "写真", Meanings: meanings("photo", "照片", "照片")},
+	{Kana: "りょこう", Kanji: "旅行", Meanings: meanings("trip", "旅行", "旅行")},
+	{Kana: "パン", Meanings: meanings("bread", "面包", "麵包")},
+	{Kana: "ペン", Meanings: meanings("pen", "笔", "筆")},
+	{Kana: "コーヒー", Meanings: meanings("coffee", "咖啡", "咖啡")},
+	{Kana: "テレビ", Meanings: meanings("television", "电视", "電視")},
+	{Kana: "カメラ", Meanings: meanings("camera", "相机", "相機")},
+	{Kana: "ファイル", Meanings: meanings("file", "文件", "檔案")},
+	{Kana: "パーティー", Meanings: meanings("party", "派对", "派對")},
+}
+
+// indexWords links each word to the characters it is spelled with. Words
+// that start with a character are listed before words that only contain it.
+func (r *Registry) indexWords() {
+	starts := make(map[int][]int)
+	contains := make(map[int][]int)
+	r.wordChars = make([][]int, len(r.words))
+
+	for i, word := range r.words {
+		seen := make(map[int]bool)
+		complete := true
+		for position, tok := range r.tokenize(word.Kana) {
+			index, ok := r.byHiragana[tok.Text]
+			if !ok {
+				index, ok = r.byKatakana[tok.Text]
+			}
+			if !ok {
+				complete = false
+				continue
+			}
+			if seen[index] {
+				continue
+			}
+			seen[index] = true
+			r.wordChars[i] = append(r.wordChars[i], index)
+			if position == 0 {
+				starts[index] = append(starts[index], i)
+			} else {
+				contains[index] = append(contains[index], i)
+			}
+		}
+		if !complete {
+			// Words with unknown kana can be shown but never practised
+			r.wordChars[i] = nil
+		}
+	}
+
+	r.byWordChar = make(map[int][]int, len(starts)+len(contains))
+	for index, words := range starts {
+		r.byWordChar[index] = words
+	}
+	for index, words := range contains {
+		r.byWordChar[index] = append(r.byWordChar[index], words...)
+	}
+}
+
+// AddWords adds example words to the registry
+func (r *Registry) AddWords(words ...Word) {
+	r.words = append(r.words, words...)
+	r.indexWords()
+}
+
+// Words returns every example word
+func (r *Registry) Words() []Word {
+	return append([]Word(nil), r.words...)
+}
+
+// WordsFor returns the example words spelled with a character, those
+// starting with it first
+func (r *Registry) WordsFor(char Character) []Word {
+	index, ok := r.byID[char.ID]
+	if !ok {
+		return nil
+	}
+	return r.collectWords(r.byWordChar[index])
+}
+
+// WordsUsing returns the example words spelled only with the given
+// characters, such as the words a learner can already read
+func (r *Registry) WordsUsing(chars []Character) []Word {
+	allowed := make(map[int]bool, len(chars))
+	for _, char := range chars {
+		if index, ok := r.byID[char.ID]; ok {
+			allowed[index] = true
+		}
+	}
+
+	var indexes []int
+	for i, spelling := range r.wordChars {
+		if len(spelling) == 0 {
+			continue
+		}
+		usable := true
+		for _, index := range spelling {
+			if !allowed[index] {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			indexes = append(indexes, i)
+		}
+	}
+	return r.collectWords(indexes)
+}
+
+// collectWords returns the words at the given positions
+func (r *Registry) collectWords(indexes []int) []Word {
+	if len(indexes) == 0 {
+		return nil
+	}
+	words := make([]Word, len(indexes))
+	for i, index := range indexes {
+		words[i] = r.words[index]
+	}
+	return words
+}
+
+// GetWordsFor returns the example words spelled with a character
+func GetWordsFor(char Character) []Word {
+	return defaultRegistry.WordsFor(char)
+}
+
+// GetWordsUsing returns the example words spelled only with the given characters
+func GetWordsUsing(chars []Character) []Word {
+	return defaultRegistry.WordsUsing(chars)
+}
+
+// RandomWord returns a random word from the list
+func RandomWord(words []Word) (Word, bool) {
+	if len(words) == 0 {
+		return Word{}, false
+	}
+	return words[rand.Intn(len(words))], true
+}
@@ -0,0 +1,127 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestEverySeionStartsAWord(t *testing.T) {
+	for _, char := range GetCharactersByCategory(Seion) {
+		// を is only used as a particle and no word starts with ん
+		if char.ID == "seion-wo" || char.ID == "seion-n" {
+			continue
+		}
+		words := GetWordsFor(char)
+		if len(words) == 0 || !startsWith(words[0].Kana, char) {
+			t.Errorf("no word starts with %s", char.Hiragana)
+		}
+	}
+}
+
+func startsWith(kana string, char Character) bool {
+	tokens := tokenize(kana)
+	return len(tokens) > 0 && (tokens[0].Text == char.Hiragana || tokens[0].Text == char.Katakana)
+}
+
+func TestWordsFor(t *testing.T) {
+	tests := []struct {
+		kana string
+		want []string
+	}{
+		{"ね", []string{"ねこ", "おかね", "ふね", "らいねん"}},
+		{"しゃ", []string{"しゃしん", "でんしゃ"}},
+		{"っ", []string{"きっぷ", "がっこう", "ざっし"}},
+		{"ー", []string{"コーヒー", "パーティー"}},
+		{"ティ", []string{"パーティー"}},
+		{"ぴゃ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			char, _ := DefaultRegistry().ByKana(tt.kana)
+			words := GetWordsFor(char)
+			if len(words) != len(tt.want) {
+				t.Fatalf("GetWordsFor(%s) = %v, want %v", tt.kana, words, tt.want)
+			}
+			for i, word := range words {
+				if word.Kana != tt.want[i] {
+					t.Errorf("GetWordsFor(%s)[%d] = %v, want %v", tt.kana, i, word.Kana, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWordsUsing(t *testing.T) {
+	var known []Character
+	for _, kana := range []string{"ね", "こ", "か", "さ", "お"} {
+		char, _ := DefaultRegistry().ByKana(kana)
+		known = append(known, char)
+	}
+
+	want := []string{"おかね", "かさ", "かお", "ここ", "ねこ"}
+	words := GetWordsUsing(known)
+	if len(words) != len(want) {
+		t.Fatalf("GetWordsUsing() = %v, want %v", words, want)
+	}
+	for i, word := range words {
+		if word.Kana != want[i] {
+			t.Errorf("GetWordsUsing()[%d] = %v, want %v", i, word.Kana, want[i])
+		}
+	}
+}
+
+func TestWordReadingAndMeaning(t *testing.T) {
+	tests := []struct {
+		word     Word
+		system   RomanizationSystem
+		language string
+		wantRead string
+		wantMean string
+		wantText string
+	}{
+		{Words[0], Hepburn, "en", "asa", "morning", "あさ 朝 (asa) morning"},
+		{Word{Kana: "ちず", Meanings: meanings("map", "地图", "地圖")}, Kunrei, "zh-tw", "tizu", "地圖", "ちず (tizu) 地圖"},
+		{Word{Kana: "しんぶん", Romaji: "shinbun", Meanings: map[string]string{"en": "newspaper"}}, Hepburn, "zh", "shinbun", "newspaper", "しんぶん (shinbun) newspaper"},
+		{Word{Kana: "らいねん"}, Hepburn, "en", "rainen", "", "らいねん (rainen)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word.Kana, func(t *testing.T) {
+			if got := tt.word.RomajiIn(tt.system); got != tt.wantRead {
+				t.Errorf("RomajiIn(%s) = %v, want %v", tt.system, got, tt.wantRead)
+			}
+			if got := tt.word.Meaning(tt.language); got != tt.wantMean {
+				t.Errorf("Meaning(%s) = %v, want %v", tt.language, got, tt.wantMean)
+			}
+			if got := tt.word.Format(tt.system, tt.language); got != tt.wantText {
+				t.Errorf("Format(%s, %s) = %q, want %q", tt.system, tt.language, got, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestRegistryMergeWords(t *testing.T) {
+	registry := NewRegistry(Characters)
+	registry.AddWords(Words...)
+	set, err := ParseCharacterSet([]byte(obsoleteKanaYAML+`
+words:
+  - kana: ゐど
+    kanji: 井戸
+    meanings: {en: well}
+`), "yaml")
+	if err != nil {
+		t.Fatalf("ParseCharacterSet() error = %v", err)
+	}
+	if err := registry.Merge(set); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	char, _ := registry.ByHiragana("ゐ")
+	words := registry.WordsFor(char)
+	if len(words) != 1 || words[0].Kanji != "井戸" {
+		t.Errorf("WordsFor(ゐ) = %v, want the merged word", words)
+	}
+	if got := len(registry.Words()); got != len(Words)+1 {
+		t.Errorf("Words() has %d words, want %d", got, len(Words)+1)
+	}
+}
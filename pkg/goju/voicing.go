@@ -0,0 +1,150 @@
+package goju
+
+import (
+	"strings"
+)
+
+// baseComposition maps a kana with dakuten or handakuten to its plain form
+var baseComposition = func() map[rune]rune {
+	base := make(map[rune]rune, len(voicedComposition)+len(semiVoicedComposition))
+	for _, table := range []map[rune]rune{voicedComposition, semiVoicedComposition} {
+		for plain, composed := range table {
+			base[composed] = plain
+		}
+	}
+	return base
+}()
+
+// Voice adds dakuten to every kana in s that takes it, so かた becomes がだ
+// and ぱ becomes ば. Other text is left unchanged.
+func Voice(s string) string {
+	return recompose(s, voicedComposition)
+}
+
+// Devoice removes dakuten and handakuten from every kana in s, so がぱ
+// becomes かは. Other text is left unchanged.
+func Devoice(s string) string {
+	return strings.Map(func(r rune) rune {
+		if plain, ok := baseComposition[r]; ok {
+			return plain
+		}
+		return r
+	}, s)
+}
+
+// Handakuten adds handakuten to every kana in s that takes it, so はば
+// becomes ぱぱ. Other text is left unchanged.
+func Handakuten(s string) string {
+	return recompose(s, semiVoicedComposition)
+}
+
+// recompose replaces each kana that has a form in table with that form,
+// starting from its plain kana
+func recompose(s string, table map[rune]rune) string {
+	return strings.Map(func(r rune) rune {
+		plain := r
+		if base, ok := baseComposition[r]; ok {
+			plain = base
+		}
+		if composed, ok := table[plain]; ok {
+			return composed
+		}
+		return r
+	}, s)
+}
+
+// linkVoicing records which characters are the dakuten and handakuten
+// forms of which. Any links left from an earlier index are replaced.
+func (r *Registry) linkVoicing() {
+	for i := range r.chars {
+		char := &r.chars[i]
+		char.Base, char.Voiced, char.SemiVoiced = "", "", ""
+		kana := char.Hiragana
+		if kana == "" {
+			kana = char.Katakana
+		}
+		if base, ok := r.ByKana(Devoice(kana)); ok && base.ID != char.ID {
+			char.Base = base.ID
+		}
+		if voiced, ok := r.ByKana(Voice(kana)); ok && voiced.ID != char.ID && char.Base == "" {
+			char.Voiced = voiced.ID
+		}
+		if semiVoiced, ok := r.ByKana(Handakuten(kana)); ok && semiVoiced.ID != char.ID && char.Base == "" {
+			char.SemiVoiced = semiVoiced.ID
+		}
+	}
+}
+
+// VoicedOf returns the dakuten form of a plain character, such as が for か
+func (r *Registry) VoicedOf(char Character) (Character, bool) {
+	return r.linked(char.ID, func(c Character) string { return c.Voiced })
+}
+
+// SemiVoicedOf returns the handakuten form of a plain character, such as
+// ぱ for は
+func (r *Registry) SemiVoicedOf(char Character) (Character, bool) {
+	return r.linked(char.ID, func(c Character) string { return c.SemiVoiced })
+}
+
+// BaseOf returns the plain form of a character with dakuten or handakuten,
+// such as は for ば and ぱ
+func (r *Registry) BaseOf(char Character) (Character, bool) {
+	return r.linked(char.ID, func(c Character) string { return c.Base })
+}
+
+// linked follows a voicing link from the registry's copy of a character,
+// so links are found even for characters built by hand
+func (r *Registry) linked(id string, link func(Character) string) (Character, bool) {
+	char, ok := r.ByID(id)
+	if !ok || link(char) == "" {
+		return Character{}, false
+	}
+	return r.ByID(link(char))
+}
+
+// VoicedOf returns the dakuten form of a plain character
+func VoicedOf(char Character) (Character, bool) {
+	return defaultRegistry.VoicedOf(char)
+}
+
+// SemiVoicedOf returns the handakuten form of a plain character
+func SemiVoicedOf(char Character) (Character, bool) {
+	return defaultRegistry.SemiVoicedOf(char)
+}
+
+// BaseOf returns the plain form of a character with dakuten or handakuten
+func BaseOf(char Character) (Character, bool) {
+	return defaultRegistry.BaseOf(char)
+}
+
+// VoicingSet is a plain character with its dakuten and handakuten forms
+type VoicingSet struct {
+	Base       Character
+	Voiced     *Character // nil when the character takes no dakuten
+	SemiVoiced *Character // nil when the character takes no handakuten
+}
+
+// VoicingTable returns every character that takes dakuten or handakuten
+// with those forms, in gojūon order
+func (r *Registry) VoicingTable() []VoicingSet {
+	var table []VoicingSet
+	for _, char := range r.chars {
+		if char.Voiced == "" && char.SemiVoiced == "" {
+			continue
+		}
+		set := VoicingSet{Base: char}
+		if voiced, ok := r.ByID(char.Voiced); ok {
+			set.Voiced = &voiced
+		}
+		if semiVoiced, ok := r.ByID(char.SemiVoiced); ok {
+			set.SemiVoiced = &semiVoiced
+		}
+		table = append(table, set)
+	}
+	return table
+}
+
+// GetVoicingTable returns the voicing table of the default registry
+func GetVoicingTable() []VoicingSet {
+	return defaultRegistry.VoicingTable()
+}
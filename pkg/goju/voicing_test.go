@@ -0,0 +1,87 @@
+package goju
+
+import (
+	"testing"
+)
+
+func TestVoicingTransforms(t *testing.T) {
+	tests := []struct {
+		input          string
+		wantVoice      string
+		wantDevoice    string
+		wantHandakuten string
+	}{
+		{"か", "が", "か", "か"},
+		{"が", "が", "か", "が"},
+		{"は", "ば", "は", "ぱ"},
+		{"ば", "ば", "は", "ぱ"},
+		{"ぱ", "ば", "は", "ぱ"},
+		{"ウ", "ヴ", "ウ", "ウ"},
+		{"きゃ", "ぎゃ", "きゃ", "きゃ"},
+		{"ゞ", "ゞ", "ゝ", "ゞ"},
+		{"すしと てんぷら", "ずじど でんぶら", "すしと てんふら", "すしと てんぷら"},
+		{"abc", "abc", "abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Voice(tt.input); got != tt.wantVoice {
+				t.Errorf("Voice(%s) = %v, want %v", tt.input, got, tt.wantVoice)
+			}
+			if got := Devoice(tt.input); got != tt.wantDevoice {
+				t.Errorf("Devoice(%s) = %v, want %v", tt.input, got, tt.wantDevoice)
+			}
+			if got := Handakuten(tt.input); got != tt.wantHandakuten {
+				t.Errorf("Handakuten(%s) = %v, want %v", tt.input, got, tt.wantHandakuten)
+			}
+		})
+	}
+}
+
+func TestVoicingLinks(t *testing.T) {
+	tests := []struct {
+		kana           string
+		wantVoiced     string
+		wantSemiVoiced string
+		wantBase       string
+	}{
+		{"か", "が", "", ""},
+		{"は", "ば", "ぱ", ""},
+		{"ば", "", "", "は"},
+		{"ぴょ", "", "", "ひょ"},
+		{"う", "ゔ", "", ""},
+		{"あ", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			char, _ := DefaultRegistry().ByKana(tt.kana)
+			voiced, _ := VoicedOf(char)
+			semiVoiced, _ := SemiVoicedOf(char)
+			base, _ := BaseOf(char)
+			if voiced.Hiragana != tt.wantVoiced || semiVoiced.Hiragana != tt.wantSemiVoiced || base.Hiragana != tt.wantBase {
+				t.Errorf("%s links to voiced %q, semi-voiced %q, base %q, want %q, %q, %q",
+					tt.kana, voiced.Hiragana, semiVoiced.Hiragana, base.Hiragana, tt.wantVoiced, tt.wantSemiVoiced, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestVoicingTable(t *testing.T) {
+	table := GetVoicingTable()
+	found := false
+	for _, set := range table {
+		if set.Voiced != nil && set.Voiced.Category == Seion {
+			t.Errorf("%s is listed as the voiced form of %s", set.Voiced.ID, set.Base.ID)
+		}
+		if set.Base.Hiragana == "は" {
+			found = set.Voiced != nil && set.Voiced.Hiragana == "ば" && set.SemiVoiced != nil && set.SemiVoiced.Hiragana == "ぱ"
+		}
+	}
+	if !found {
+		t.Error("voicing table has no は → ば → ぱ row")
+	}
+	if len(GetCharactersByCategory(Dakuon)) > len(table) {
+		t.Errorf("voicing table has %d rows, fewer than the dakuon", len(table))
+	}
+}